@@ -0,0 +1,142 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+	"github.com/warjiang/karmada-mcp-server/pkg/environment"
+	"github.com/warjiang/karmada-mcp-server/pkg/karmada"
+	"github.com/warjiang/karmada-mcp-server/pkg/metrics"
+	"github.com/warjiang/karmada-mcp-server/pkg/oauth"
+	"k8s.io/klog/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// sessionIdleTTL is how long a Streamable HTTP session may go without requests before it is terminated.
+const sessionIdleTTL = 30 * time.Minute
+
+func NewHttpCommand() *cobra.Command {
+	opts := newHttpServerOptions()
+	cmd := &cobra.Command{
+		Use:   "http",
+		Short: "Start streamable http server",
+		Long:  `Start a server that communicates via the MCP Streamable HTTP transport, JSON-RPC messages are posted to a single endpoint and responses are returned as JSON or streamed as server-sent events, sessions are tracked with the Mcp-Session-Id header.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			httpServerConfig := HttpServerOptions{
+				Version:                   environment.Version(),
+				EnabledToolsets:           opts.EnabledToolsets,
+				ReadOnly:                  opts.ReadOnly,
+				DynamicToolsets:           opts.DynamicToolsets,
+				ToolPolicyFile:            opts.ToolPolicyFile,
+				Audit:                     opts.Audit,
+				ConfirmDestructiveTools:   opts.ConfirmDestructiveTools,
+				ConfirmationTTL:           opts.ConfirmationTTL,
+				Address:                   opts.Address,
+				EndpointPath:              opts.EndpointPath,
+				TrustImpersonationHeaders: opts.TrustImpersonationHeaders,
+				OAuth:                     opts.OAuth,
+			}
+			if err := httpServerConfig.Validate(); err != nil {
+				return err
+			}
+			return runHttpServer(httpServerConfig)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runHttpServer(opts HttpServerOptions) error {
+	klog.Info("Starting mcp server in http mode")
+
+	// Create app context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	karmadaServer, subscriptions, err := karmada.NewMCPServer(karmada.MCPServerConfig{
+		Version:         opts.Version,
+		EnabledToolsets: opts.EnabledToolsets,
+		ReadOnly:        opts.ReadOnly,
+		DynamicToolsets: opts.DynamicToolsets,
+		ToolPolicyFile:  opts.ToolPolicyFile,
+		Audit:           opts.Audit,
+		Confirmation:    karmada.ConfirmationMode(opts.ConfirmDestructiveTools),
+		ConfirmationTTL: opts.ConfirmationTTL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	srv := &http.Server{Addr: opts.Address, Handler: mux}
+	// The stateful session manager only accepts the session ids it generated and forgets them on DELETE,
+	// requests of unknown or terminated sessions get 404 Not Found so that the client starts a new session.
+	// Sessions idle for sessionIdleTTL are terminated too, since clients going away do not always send DELETE.
+	sessions := &server.InsecureStatefulSessionIdManager{}
+	httpServer := server.NewStreamableHTTPServer(karmadaServer,
+		server.WithEndpointPath(opts.EndpointPath),
+		server.WithHTTPContextFunc(karmada.NewHTTPContextFunc(opts.TrustImpersonationHeaders)),
+		server.WithSessionIdManager(sessions),
+		server.WithSessionIdleTTL(sessionIdleTTL),
+		server.WithStreamableHTTPServer(srv),
+	)
+
+	handler := validateSession(sessions, subscriptions.Handler(httpServer))
+	if opts.OAuth.Enabled() {
+		authenticator, err := oauth.NewAuthenticator(ctx, opts.OAuth)
+		if err != nil {
+			return fmt.Errorf("failed to create OAuth authenticator: %w", err)
+		}
+		handler = authenticator.Handler(handler, opts.EndpointPath)
+		mux.Handle(oauth.ProtectedResourceMetadataPath+"/", handler)
+		mux.Handle(oauth.ProtectedResourceMetadataPath, handler)
+	}
+	mux.Handle(opts.EndpointPath, handler)
+	// metrics are served without authorization so that Prometheus can scrape them
+	mux.Handle("/metrics", metrics.Handler())
+
+	// Start listening for messages
+	errC := make(chan error, 1)
+	go func() {
+		klog.Infof("mcp server in http mode started on %s%s", opts.Address, opts.EndpointPath)
+		errC <- httpServer.Start(opts.Address)
+	}()
+
+	// Wait for shutdown signal
+	select {
+	case <-ctx.Done():
+		klog.Info("shutting down http server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("error shutting down server: %w", err)
+		}
+	case err := <-errC:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("error running server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateSession rejects the GET and DELETE requests of unknown or terminated sessions with 404 Not Found,
+// mcp-go only validates the session of POST requests and would open a stream for any session id.
+func validateSession(sessions server.SessionIdManager, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get(server.HeaderKeySessionID)
+		if sessionID != "" && (r.Method == http.MethodGet || r.Method == http.MethodDelete) {
+			if terminated, err := sessions.Validate(sessionID); err != nil || terminated {
+				http.Error(w, "Session not found", http.StatusNotFound)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
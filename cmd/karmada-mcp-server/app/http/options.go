@@ -0,0 +1,78 @@
+package http
+
+import (
+	"github.com/spf13/pflag"
+	"github.com/warjiang/karmada-mcp-server/pkg/audit"
+	"github.com/warjiang/karmada-mcp-server/pkg/karmada"
+	"github.com/warjiang/karmada-mcp-server/pkg/oauth"
+	"time"
+)
+
+type HttpServerOptions struct {
+	// Version of the server
+	Version string
+
+	// EnabledToolsets is a list of toolsets to enable
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
+	EnabledToolsets []string
+
+	// ReadOnly indicates if we should only register read-only tools
+	ReadOnly bool
+
+	// DynamicToolsets indicates if toolsets are enabled on demand by the client instead of at startup
+	DynamicToolsets bool
+
+	// ToolPolicyFile is a YAML file allowing or denying tool calls
+	ToolPolicyFile string
+
+	// ConfirmDestructiveTools selects how the calls of destructive tools are confirmed: auto, token or none
+	ConfirmDestructiveTools string
+
+	// ConfirmationTTL is how long a confirmation token is valid
+	ConfirmationTTL time.Duration
+
+	// Audit configures the audit log of tool calls
+	Audit audit.Options
+
+	// Address is the address the streamable http server listens on
+	Address string
+
+	// EndpointPath is the path of the single MCP endpoint
+	EndpointPath string
+
+	// TrustImpersonationHeaders honors Impersonate-* headers of requests without a bearer token,
+	// the server then impersonates the user with its own identity
+	TrustImpersonationHeaders bool
+
+	// OAuth configures the OAuth 2.1 / OIDC authorization of MCP clients
+	OAuth oauth.Options
+}
+
+// newHttpServerOptions returns initialized HttpServerOptions.
+func newHttpServerOptions() *HttpServerOptions {
+	return &HttpServerOptions{}
+}
+
+// AddFlags adds flags of api to the specified FlagSet
+func (o *HttpServerOptions) AddFlags(fs *pflag.FlagSet) {
+	if o == nil {
+		return
+	}
+
+	fs.StringSliceVar(&o.EnabledToolsets, "toolsets", karmada.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
+	fs.BoolVar(&o.ReadOnly, "read-only", false, "Restrict the server to read-only operations")
+	fs.BoolVar(&o.DynamicToolsets, "dynamic-toolsets", false, "Start with only the list_available_toolsets, get_toolset_tools and enable_toolset tools and enable toolsets when the client asks for them, toolsets other than all passed to --toolsets are still enabled at startup")
+	fs.StringVar(&o.ToolPolicyFile, "tool-policy-file", "", "A YAML file allowing or denying tool calls by tool, namespace, kind and argument values, denied calls are returned as tool errors")
+	fs.StringVar(&o.ConfirmDestructiveTools, "confirm-destructive-tools", string(karmada.ConfirmationNone), "How calls of destructive tools such as deletions are confirmed: none executes them immediately, auto asks the user via elicitation if the client supports it and falls back to token, token requires the call to be repeated with the token returned by the first call. With auto and token the first call of a destructive tool no longer executes it, which breaks automation that can not confirm")
+	fs.DurationVar(&o.ConfirmationTTL, "confirmation-ttl", 5*time.Minute, "How long the confirmation token of a destructive call is valid")
+	o.Audit.AddFlags(fs)
+	fs.StringVar(&o.Address, "address", "localhost:1234", "The address the streamable http server listens on")
+	fs.StringVar(&o.EndpointPath, "endpoint-path", "/mcp", "The path of the MCP endpoint")
+	fs.BoolVar(&o.TrustImpersonationHeaders, "trust-impersonation-headers", false, "Honor Impersonate-User/Group/Uid/Extra-* headers of requests without a bearer token by impersonating with the identity of the server, only enable it behind a proxy which authenticates clients and sets these headers itself")
+	o.OAuth.AddFlags(fs)
+}
+
+// Validate checks the flags are consistent.
+func (o *HttpServerOptions) Validate() error {
+	return o.OAuth.Validate()
+}
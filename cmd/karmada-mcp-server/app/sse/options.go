@@ -1,8 +1,12 @@
 package sse
 
 import (
+	"fmt"
 	"github.com/spf13/pflag"
+	"github.com/warjiang/karmada-mcp-server/pkg/audit"
 	"github.com/warjiang/karmada-mcp-server/pkg/karmada"
+	"github.com/warjiang/karmada-mcp-server/pkg/oauth"
+	"time"
 )
 
 type SseServerOptions struct {
@@ -15,6 +19,48 @@ type SseServerOptions struct {
 
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
+
+	// DynamicToolsets indicates if toolsets are enabled on demand by the client instead of at startup
+	DynamicToolsets bool
+
+	// ToolPolicyFile is a YAML file allowing or denying tool calls
+	ToolPolicyFile string
+
+	// ConfirmDestructiveTools selects how the calls of destructive tools are confirmed: auto, token or none
+	ConfirmDestructiveTools string
+
+	// ConfirmationTTL is how long a confirmation token is valid
+	ConfirmationTTL time.Duration
+
+	// Audit configures the audit log of tool calls
+	Audit audit.Options
+
+	// Address is the address the sse server listens on
+	Address string
+
+	// BasePath is the path prefix of the sse and message endpoints
+	BasePath string
+
+	// BaseURL is the external URL clients reach the server at, e.g. when it runs behind a Service or ingress,
+	// it is used to build the message endpoint announced to clients
+	BaseURL string
+
+	// TLSCertFile is the file containing the x509 certificate for serving over https
+	TLSCertFile string
+
+	// TLSPrivateKeyFile is the file containing the x509 private key matching TLSCertFile
+	TLSPrivateKeyFile string
+
+	// ClientCAFile is the file containing the CA bundle client certificates are verified against,
+	// client certificates are required if it is set
+	ClientCAFile string
+
+	// TrustImpersonationHeaders honors Impersonate-* headers of requests without a bearer token,
+	// the server then impersonates the user with its own identity
+	TrustImpersonationHeaders bool
+
+	// OAuth configures the OAuth 2.1 / OIDC authorization of MCP clients
+	OAuth oauth.Options
 }
 
 // newSseServerOptions returns initialized SseServerOptions.
@@ -30,4 +76,28 @@ func (o *SseServerOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.StringSliceVar(&o.EnabledToolsets, "toolsets", karmada.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
 	fs.BoolVar(&o.ReadOnly, "read-only", false, "Restrict the server to read-only operations")
+	fs.BoolVar(&o.DynamicToolsets, "dynamic-toolsets", false, "Start with only the list_available_toolsets, get_toolset_tools and enable_toolset tools and enable toolsets when the client asks for them, toolsets other than all passed to --toolsets are still enabled at startup")
+	fs.StringVar(&o.ToolPolicyFile, "tool-policy-file", "", "A YAML file allowing or denying tool calls by tool, namespace, kind and argument values, denied calls are returned as tool errors")
+	fs.StringVar(&o.ConfirmDestructiveTools, "confirm-destructive-tools", string(karmada.ConfirmationNone), "How calls of destructive tools such as deletions are confirmed: none executes them immediately, auto asks the user via elicitation if the client supports it and falls back to token, token requires the call to be repeated with the token returned by the first call. With auto and token the first call of a destructive tool no longer executes it, which breaks automation that can not confirm")
+	fs.DurationVar(&o.ConfirmationTTL, "confirmation-ttl", 5*time.Minute, "How long the confirmation token of a destructive call is valid")
+	o.Audit.AddFlags(fs)
+	fs.StringVar(&o.Address, "address", "localhost:1234", "The address the sse server listens on, use 0.0.0.0:1234 to accept connections from outside the pod")
+	fs.StringVar(&o.BasePath, "base-path", "/mcp", "The path prefix of the sse and message endpoints")
+	fs.StringVar(&o.BaseURL, "base-url", "", "The external URL clients reach the server at, e.g. https://karmada-mcp.example.com, defaults to the host of the request")
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", "", "File containing the x509 certificate for serving over https, the file is reloaded on change")
+	fs.StringVar(&o.TLSPrivateKeyFile, "tls-private-key-file", "", "File containing the x509 private key matching --tls-cert-file, the file is reloaded on change")
+	fs.StringVar(&o.ClientCAFile, "client-ca-file", "", "File containing the CA bundle to verify client certificates against, enables mutual TLS, the file is reloaded on change")
+	fs.BoolVar(&o.TrustImpersonationHeaders, "trust-impersonation-headers", false, "Honor Impersonate-User/Group/Uid/Extra-* headers of requests without a bearer token by impersonating with the identity of the server, only enable it behind a proxy which authenticates clients and sets these headers itself")
+	o.OAuth.AddFlags(fs)
+}
+
+// Validate checks the flags are consistent.
+func (o *SseServerOptions) Validate() error {
+	if (o.TLSCertFile == "") != (o.TLSPrivateKeyFile == "") {
+		return fmt.Errorf("--tls-cert-file and --tls-private-key-file must be set together")
+	}
+	if o.ClientCAFile != "" && o.TLSCertFile == "" {
+		return fmt.Errorf("--client-ca-file requires --tls-cert-file and --tls-private-key-file")
+	}
+	return o.OAuth.Validate()
 }
@@ -2,15 +2,21 @@ package sse
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/cobra"
+	"github.com/warjiang/karmada-mcp-server/pkg/certs"
 	"github.com/warjiang/karmada-mcp-server/pkg/environment"
 	"github.com/warjiang/karmada-mcp-server/pkg/karmada"
+	"github.com/warjiang/karmada-mcp-server/pkg/metrics"
+	"github.com/warjiang/karmada-mcp-server/pkg/oauth"
 	"k8s.io/klog/v2"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func NewSseCommand() *cobra.Command {
@@ -18,12 +24,28 @@ func NewSseCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sse",
 		Short: "Start sse server",
-		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
+		Long:  `Start a server that communicates via server-sent events, optionally over https with mutual TLS.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			sseServerConfig := SseServerOptions{
-				Version:         environment.Version(),
-				EnabledToolsets: opts.EnabledToolsets,
-				ReadOnly:        opts.ReadOnly,
+				Version:                   environment.Version(),
+				EnabledToolsets:           opts.EnabledToolsets,
+				ReadOnly:                  opts.ReadOnly,
+				DynamicToolsets:           opts.DynamicToolsets,
+				ToolPolicyFile:            opts.ToolPolicyFile,
+				Audit:                     opts.Audit,
+				ConfirmDestructiveTools:   opts.ConfirmDestructiveTools,
+				ConfirmationTTL:           opts.ConfirmationTTL,
+				Address:                   opts.Address,
+				BasePath:                  opts.BasePath,
+				BaseURL:                   opts.BaseURL,
+				TLSCertFile:               opts.TLSCertFile,
+				TLSPrivateKeyFile:         opts.TLSPrivateKeyFile,
+				ClientCAFile:              opts.ClientCAFile,
+				TrustImpersonationHeaders: opts.TrustImpersonationHeaders,
+				OAuth:                     opts.OAuth,
+			}
+			if err := sseServerConfig.Validate(); err != nil {
+				return err
 			}
 			return runSseServer(sseServerConfig)
 		},
@@ -39,33 +61,77 @@ func runSseServer(opts SseServerOptions) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	karmadaServer, err := karmada.NewMCPServer(karmada.MCPServerConfig{
+	karmadaServer, subscriptions, err := karmada.NewMCPServer(karmada.MCPServerConfig{
 		Version:         opts.Version,
 		EnabledToolsets: opts.EnabledToolsets,
 		ReadOnly:        opts.ReadOnly,
+		DynamicToolsets: opts.DynamicToolsets,
+		ToolPolicyFile:  opts.ToolPolicyFile,
+		Audit:           opts.Audit,
+		Confirmation:    karmada.ConfirmationMode(opts.ConfirmDestructiveTools),
+		ConfirmationTTL: opts.ConfirmationTTL,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
 
-	sseServer := server.NewSSEServer(karmadaServer,
-		//server.WithBaseURL("http://localhost:5173"),
-		server.WithStaticBasePath("/mcp"),
-	)
+	httpServer := &http.Server{Addr: opts.Address}
+	sseOptions := []server.SSEOption{
+		server.WithStaticBasePath(opts.BasePath),
+		server.WithHTTPServer(httpServer),
+		server.WithSSEContextFunc(karmada.NewHTTPContextFunc(opts.TrustImpersonationHeaders)),
+	}
+	if opts.BaseURL != "" {
+		sseOptions = append(sseOptions, server.WithBaseURL(opts.BaseURL))
+	}
+	sseServer := server.NewSSEServer(karmadaServer, sseOptions...)
+	handler := subscriptions.Handler(sseServer)
+	if opts.OAuth.Enabled() {
+		authenticator, err := oauth.NewAuthenticator(ctx, opts.OAuth)
+		if err != nil {
+			return fmt.Errorf("failed to create OAuth authenticator: %w", err)
+		}
+		handler = authenticator.Handler(handler, opts.BasePath)
+	}
+	// metrics are served without authorization so that Prometheus can scrape them
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/", handler)
+	httpServer.Handler = mux
+
+	if opts.TLSCertFile != "" {
+		certWatcher, err := certs.NewWatcher(opts.TLSCertFile, opts.TLSPrivateKeyFile, opts.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		if err := certWatcher.Start(ctx); err != nil {
+			return err
+		}
+		httpServer.TLSConfig = certWatcher.TLSConfig()
+	}
 
 	// Start listening for messages
 	errC := make(chan error, 1)
 	go func() {
-		klog.Info("mcp server in sse mode started")
-		errC <- sseServer.Start("localhost:1234")
+		klog.Infof("mcp server in sse mode started on %s%s", opts.Address, opts.BasePath)
+		if httpServer.TLSConfig != nil {
+			errC <- httpServer.ListenAndServeTLS("", "")
+			return
+		}
+		errC <- httpServer.ListenAndServe()
 	}()
 
 	// Wait for shutdown signal
 	select {
 	case <-ctx.Done():
 		klog.Info("shutting down sse server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := sseServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("error shutting down server: %w", err)
+		}
 	case err := <-errC:
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			return fmt.Errorf("error running server: %w", err)
 		}
 	}
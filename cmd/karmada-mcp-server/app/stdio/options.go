@@ -1,8 +1,11 @@
 package stdio
 
 import (
+	"fmt"
 	"github.com/spf13/pflag"
+	"github.com/warjiang/karmada-mcp-server/pkg/audit"
 	"github.com/warjiang/karmada-mcp-server/pkg/karmada"
+	"time"
 )
 
 type StdioServerOptions struct {
@@ -15,6 +18,21 @@ type StdioServerOptions struct {
 
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
+
+	// DynamicToolsets indicates if toolsets are enabled on demand by the client instead of at startup
+	DynamicToolsets bool
+
+	// ToolPolicyFile is a YAML file allowing or denying tool calls
+	ToolPolicyFile string
+
+	// ConfirmDestructiveTools selects how the calls of destructive tools are confirmed: auto, token or none
+	ConfirmDestructiveTools string
+
+	// ConfirmationTTL is how long a confirmation token is valid
+	ConfirmationTTL time.Duration
+
+	// Audit configures the audit log of tool calls
+	Audit audit.Options
 }
 
 // newStdioServerOptions returns initialized StdioServerOptions.
@@ -30,4 +48,17 @@ func (o *StdioServerOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.StringSliceVar(&o.EnabledToolsets, "toolsets", karmada.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
 	fs.BoolVar(&o.ReadOnly, "read-only", false, "Restrict the server to read-only operations")
+	fs.BoolVar(&o.DynamicToolsets, "dynamic-toolsets", false, "Start with only the list_available_toolsets, get_toolset_tools and enable_toolset tools and enable toolsets when the client asks for them, toolsets other than all passed to --toolsets are still enabled at startup")
+	fs.StringVar(&o.ToolPolicyFile, "tool-policy-file", "", "A YAML file allowing or denying tool calls by tool, namespace, kind and argument values, denied calls are returned as tool errors")
+	fs.StringVar(&o.ConfirmDestructiveTools, "confirm-destructive-tools", string(karmada.ConfirmationNone), "How calls of destructive tools such as deletions are confirmed: none executes them immediately, auto asks the user via elicitation if the client supports it and falls back to token, token requires the call to be repeated with the token returned by the first call. With auto and token the first call of a destructive tool no longer executes it, which breaks automation that can not confirm")
+	fs.DurationVar(&o.ConfirmationTTL, "confirmation-ttl", 5*time.Minute, "How long the confirmation token of a destructive call is valid")
+	o.Audit.AddFlags(fs)
+}
+
+// Validate checks the flags are consistent.
+func (o *StdioServerOptions) Validate() error {
+	if o.Audit.Path == "-" {
+		return fmt.Errorf("--audit-log-path can not be stdout in stdio mode, stdout carries the MCP messages")
+	}
+	return nil
 }
@@ -21,9 +21,17 @@ func NewStdioCommand() *cobra.Command {
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			stdioServerConfig := StdioServerOptions{
-				Version:         environment.Version(),
-				EnabledToolsets: opts.EnabledToolsets,
-				ReadOnly:        opts.ReadOnly,
+				Version:                 environment.Version(),
+				EnabledToolsets:         opts.EnabledToolsets,
+				ReadOnly:                opts.ReadOnly,
+				DynamicToolsets:         opts.DynamicToolsets,
+				ToolPolicyFile:          opts.ToolPolicyFile,
+				Audit:                   opts.Audit,
+				ConfirmDestructiveTools: opts.ConfirmDestructiveTools,
+				ConfirmationTTL:         opts.ConfirmationTTL,
+			}
+			if err := stdioServerConfig.Validate(); err != nil {
+				return err
 			}
 			return runStdioServer(stdioServerConfig)
 		},
@@ -39,10 +47,15 @@ func runStdioServer(opts StdioServerOptions) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	karmadaServer, err := karmada.NewMCPServer(karmada.MCPServerConfig{
+	karmadaServer, subscriptions, err := karmada.NewMCPServer(karmada.MCPServerConfig{
 		Version:         opts.Version,
 		EnabledToolsets: opts.EnabledToolsets,
 		ReadOnly:        opts.ReadOnly,
+		DynamicToolsets: opts.DynamicToolsets,
+		ToolPolicyFile:  opts.ToolPolicyFile,
+		Audit:           opts.Audit,
+		Confirmation:    karmada.ConfirmationMode(opts.ConfirmDestructiveTools),
+		ConfirmationTTL: opts.ConfirmationTTL,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -54,7 +67,7 @@ func runStdioServer(opts StdioServerOptions) error {
 	errC := make(chan error, 1)
 	go func() {
 		klog.Info("mcp server in stdio mode started")
-		errC <- stdioServer.Listen(ctx, os.Stdin, os.Stdout)
+		errC <- stdioServer.Listen(ctx, subscriptions.Reader(os.Stdin), os.Stdout)
 	}()
 
 	// Wait for shutdown signal
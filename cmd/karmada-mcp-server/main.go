@@ -6,6 +6,7 @@ import (
 	"github.com/karmada-io/karmada/pkg/sharedcli/klogflag"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/warjiang/karmada-mcp-server/cmd/karmada-mcp-server/app/http"
 	"github.com/warjiang/karmada-mcp-server/cmd/karmada-mcp-server/app/sse"
 	"github.com/warjiang/karmada-mcp-server/cmd/karmada-mcp-server/app/stdio"
 	"github.com/warjiang/karmada-mcp-server/pkg/environment"
@@ -44,6 +45,7 @@ func init() {
 	// Add subcommands
 	rootCmd.AddCommand(stdio.NewStdioCommand())
 	rootCmd.AddCommand(sse.NewSseCommand())
+	rootCmd.AddCommand(http.NewHttpCommand())
 
 }
 
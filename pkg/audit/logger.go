@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"k8s.io/klog/v2"
+	"os"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a tool call.
+type Status string
+
+const (
+	// StatusSuccess is recorded for calls returning a result
+	StatusSuccess Status = "success"
+	// StatusError is recorded for calls returning a tool error, such as calls denied by the tool policy
+	StatusError Status = "error"
+	// StatusFailure is recorded for calls whose handler failed
+	StatusFailure Status = "failure"
+)
+
+// Entry is one line of the audit log.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"requestId,omitempty"`
+	SessionID string    `json:"sessionId,omitempty"`
+	User      *User     `json:"user"`
+	Tool      string    `json:"tool"`
+	// Resource is the uri of a resources/read, which is recorded with the tool reading the same object
+	Resource  string                 `json:"resource,omitempty"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Target    interface{}            `json:"target,omitempty"`
+	Status    Status                 `json:"status"`
+	Error     string                 `json:"error,omitempty"`
+	Duration  float64                `json:"durationMs"`
+}
+
+// User is the identity a tool call acts with on the Karmada apiserver.
+type User struct {
+	// Server is set if the call acts with the identity of the server
+	Server bool     `json:"server,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// TokenHash is the truncated SHA-256 of a forwarded bearer token, which identifies the caller without exposing the token
+	TokenHash string `json:"tokenHash,omitempty"`
+}
+
+// Logger writes audit entries as JSON lines, separate from the klog output.
+type Logger struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewLogger returns the logger configured by options, nil if the audit log is disabled.
+func NewLogger(options Options) (*Logger, error) {
+	switch options.Path {
+	case "":
+		return nil, nil
+	case "-":
+		return &Logger{writer: os.Stdout}, nil
+	}
+	file, err := newRotatingFile(options.Path, options.MaxSize, options.MaxBackup, options.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{writer: file}, nil
+}
+
+// Log writes the entry, failures are logged with klog because a tool call must not fail on auditing.
+func (l *Logger) Log(entry *Entry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		klog.Errorf("failed to marshal audit entry of tool %s, err: %v", entry.Tool, err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := fmt.Fprintf(l.writer, "%s\n", line); err != nil {
+		klog.Errorf("failed to write audit entry of tool %s, err: %v", entry.Tool, err)
+	}
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// Options configures the audit log of tool calls.
+type Options struct {
+	// Path of the audit log file, - writes to stdout, the audit log is disabled if empty
+	Path string
+
+	// MaxSize is the size in megabytes the file is rotated at
+	MaxSize int
+
+	// MaxBackup is the number of rotated files to keep, 0 keeps all
+	MaxBackup int
+
+	// MaxAge is the number of days to keep rotated files, 0 keeps them regardless of their age
+	MaxAge int
+}
+
+// AddFlags adds flags of api to the specified FlagSet
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	if o == nil {
+		return
+	}
+
+	fs.StringVar(&o.Path, "audit-log-path", "", "If set, every tool call is recorded as a JSON line in this file, - writes to stdout")
+	fs.IntVar(&o.MaxSize, "audit-log-maxsize", 100, "The size in megabytes the audit log file is rotated at")
+	fs.IntVar(&o.MaxBackup, "audit-log-maxbackup", 10, "The number of rotated audit log files to keep, 0 keeps all")
+	fs.IntVar(&o.MaxAge, "audit-log-maxage", 0, "The number of days to keep rotated audit log files, 0 keeps them regardless of their age")
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp inserted into the names of rotated files, it sorts chronologically.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// rotatingFile is an append-only file which is renamed to <name>-<timestamp><ext> once it reaches maxSize.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func newRotatingFile(path string, maxSizeMB, maxBackups, maxAgeDays int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory of audit log %s: %w", path, err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat audit log %s: %w", f.path, err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log %s: %w", f.path, err)
+	}
+	ext := filepath.Ext(f.path)
+	backup := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(f.path, ext), time.Now().UTC().Format(backupTimeFormat), ext)
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate audit log %s: %w", f.path, err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.removeBackups()
+	return nil
+}
+
+// removeBackups deletes the rotated files exceeding maxBackups or older than maxAge, failures only leave files behind.
+func (f *rotatingFile) removeBackups() {
+	if f.maxBackups == 0 && f.maxAge == 0 {
+		return
+	}
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(filepath.Base(f.path), ext) + "-"
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return
+	}
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)); err != nil {
+			continue
+		}
+		backups = append(backups, name)
+	}
+	// newest first
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	for i, name := range backups {
+		expired := false
+		if f.maxAge > 0 {
+			rotatedAt, _ := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+			expired = time.Since(rotatedAt) > f.maxAge
+		}
+		if (f.maxBackups > 0 && i >= f.maxBackups) || expired {
+			_ = os.Remove(filepath.Join(filepath.Dir(f.path), name))
+		}
+	}
+}
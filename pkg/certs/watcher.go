@@ -0,0 +1,139 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog/v2"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Watcher holds a serving certificate and an optional client CA bundle loaded from files,
+// both are reloaded whenever the files change on disk so that rotated certificates are
+// picked up without restarting the server.
+type Watcher struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// NewWatcher loads the certificate, key and client CA bundle, clientCAFile is optional.
+func NewWatcher(certFile, keyFile, clientCAFile string) (*Watcher, error) {
+	w := &Watcher{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Watcher) load() error {
+	cert, err := tls.LoadX509KeyPair(w.certFile, w.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate %s and key %s: %w", w.certFile, w.keyFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if w.clientCAFile != "" {
+		pem, err := os.ReadFile(w.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file %s: %w", w.clientCAFile, err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in client CA file %s", w.clientCAFile)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cert = &cert
+	w.clientCAs = clientCAs
+	return nil
+}
+
+// Start watches the directories of the files and reloads them on change until ctx is done.
+// Directories rather than files are watched, because Secrets mounted into a pod are
+// updated by swapping a symlink, which never touches the watched file itself.
+func (w *Watcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	dirs := map[string]struct{}{}
+	for _, file := range []string{w.certFile, w.keyFile, w.clientCAFile} {
+		if file == "" {
+			continue
+		}
+		dir := filepath.Dir(file)
+		if _, ok := dirs[dir]; ok {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		dirs[dir] = struct{}{}
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) && !event.Has(fsnotify.Rename) && !event.Has(fsnotify.Remove) {
+					continue
+				}
+				// Keep serving the previous certificate if the new files are incomplete, the
+				// next event of the update will trigger another attempt.
+				if err := w.load(); err != nil {
+					klog.V(2).Infof("failed to reload certificates after %s, err: %v", event, err)
+					continue
+				}
+				klog.Infof("reloaded certificates after %s", event)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				klog.Errorf("failed to watch certificates, err: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// TLSConfig returns a server TLS config which always serves the latest loaded certificate,
+// client certificates are required and verified against the client CA bundle if one is set.
+func (w *Watcher) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			w.mu.RLock()
+			defer w.mu.RUnlock()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*w.cert},
+			}
+			if w.clientCAs != nil {
+				config.ClientCAs = w.clientCAs
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
@@ -0,0 +1,197 @@
+package karmada
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/warjiang/karmada-mcp-server/pkg/audit"
+	"github.com/warjiang/karmada-mcp-server/pkg/toolpolicy"
+	"regexp"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveArgument matches the names of arguments whose values are never written to the audit log.
+var sensitiveArgument = regexp.MustCompile(`(?i)(token|password|passwd|passphrase|credentials?|privatekey|secret)$`)
+
+// addAuditHooks records every tool call and resource read in the audit log once it completes.
+func addAuditHooks(hooks *server.Hooks, logger *audit.Logger) {
+	calls := &callTracker{}
+	hooks.AddBeforeCallTool(calls.start)
+	hooks.AddAfterCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest, result any) {
+		entry := newAuditEntry(ctx, id, message)
+		entry.Duration = durationMs(calls.stop(ctx, id))
+		entry.Status = audit.StatusSuccess
+		if result, ok := result.(*mcp.CallToolResult); ok && result.IsError {
+			entry.Status = audit.StatusError
+			entry.Error = resultText(result)
+		}
+		logger.Log(entry)
+	})
+	hooks.AddBeforeReadResource(func(ctx context.Context, id any, _ *mcp.ReadResourceRequest) {
+		calls.start(ctx, id, nil)
+	})
+	hooks.AddAfterReadResource(func(ctx context.Context, id any, message *mcp.ReadResourceRequest, _ *mcp.ReadResourceResult) {
+		entry := newResourceAuditEntry(ctx, id, message)
+		entry.Duration = durationMs(calls.stop(ctx, id))
+		entry.Status = audit.StatusSuccess
+		logger.Log(entry)
+	})
+	hooks.AddOnError(func(ctx context.Context, id any, method mcp.MCPMethod, message any, err error) {
+		var entry *audit.Entry
+		switch request := message.(type) {
+		case *mcp.CallToolRequest:
+			if method != mcp.MethodToolsCall {
+				return
+			}
+			entry = newAuditEntry(ctx, id, request)
+		case *mcp.ReadResourceRequest:
+			if method != mcp.MethodResourcesRead {
+				return
+			}
+			entry = newResourceAuditEntry(ctx, id, request)
+		default:
+			return
+		}
+		entry.Duration = durationMs(calls.stop(ctx, id))
+		entry.Status = audit.StatusFailure
+		entry.Error = err.Error()
+		logger.Log(entry)
+	})
+}
+
+func newAuditEntry(ctx context.Context, id any, request *mcp.CallToolRequest) *audit.Entry {
+	tool, _ := toolpolicy.CalledTool(ctx, request.Params.Name)
+	return newToolAuditEntry(ctx, id, tool, request)
+}
+
+func newToolAuditEntry(ctx context.Context, id any, tool mcp.Tool, request *mcp.CallToolRequest) *audit.Entry {
+	arguments := request.GetArguments()
+	entry := &audit.Entry{
+		Time:      time.Now(),
+		RequestID: fmt.Sprint(id),
+		SessionID: sessionID(ctx),
+		User:      auditUser(CredentialsFromContext(ctx)),
+		Tool:      request.Params.Name,
+		Arguments: redactArguments(arguments),
+	}
+	// the target is recorded even if the arguments contradict the content, those calls are denied by the tool policy
+	if target, _ := toolpolicy.TargetOf(tool, arguments); target != (toolpolicy.Target{}) {
+		entry.Target = target
+	}
+	return entry
+}
+
+// newResourceAuditEntry records a resource read as the call of the get tool reading the same object.
+func newResourceAuditEntry(ctx context.Context, id any, request *mcp.ReadResourceRequest) *audit.Entry {
+	tool, call := resourceToolCall(request.Params.URI)
+	entry := newToolAuditEntry(ctx, id, tool, &call)
+	entry.Resource = request.Params.URI
+	return entry
+}
+
+// callTracker measures the duration of the tool calls and resource reads in flight, keyed by session and request ID.
+type callTracker struct {
+	started sync.Map
+}
+
+func (t *callTracker) start(ctx context.Context, id any, _ *mcp.CallToolRequest) {
+	t.started.Store(sessionID(ctx)+"/"+fmt.Sprint(id), time.Now())
+}
+
+// stop returns the duration of the call, zero if its start was not seen.
+func (t *callTracker) stop(ctx context.Context, id any) time.Duration {
+	start, ok := t.started.LoadAndDelete(sessionID(ctx) + "/" + fmt.Sprint(id))
+	if !ok {
+		return 0
+	}
+	return time.Since(start.(time.Time))
+}
+
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+func auditUser(credentials *Credentials) *audit.User {
+	if credentials == nil {
+		return &audit.User{Server: true}
+	}
+	user := &audit.User{
+		Name:   credentials.Impersonate.UserName,
+		Groups: credentials.Impersonate.Groups,
+	}
+	if credentials.BearerToken != "" {
+		sum := sha256.Sum256([]byte(credentials.BearerToken))
+		user.TokenHash = hex.EncodeToString(sum[:8])
+	}
+	return user
+}
+
+// redactArguments returns a copy of the arguments without secrets, the values of sensitive arguments and
+// the data of Secret manifests are replaced.
+func redactArguments(arguments map[string]interface{}) map[string]interface{} {
+	if len(arguments) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(arguments))
+	for name, value := range arguments {
+		switch {
+		case sensitiveArgument.MatchString(name):
+			result[name] = redacted
+		case name == "content":
+			result[name] = redactManifest(value)
+		default:
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func redactManifest(value interface{}) interface{} {
+	content, ok := value.(string)
+	if !ok {
+		return value
+	}
+	manifest := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(content), &manifest); err != nil || manifest["kind"] != "Secret" {
+		return value
+	}
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := manifest[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range data {
+			data[key] = redacted
+		}
+	}
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func durationMs(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
@@ -0,0 +1,265 @@
+package karmada
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// bindingSummary is the view of a ResourceBinding or ClusterResourceBinding returned by the binding tools.
+type bindingSummary struct {
+	Name                  string                              `json:"name"`
+	Namespace             string                              `json:"namespace,omitempty"`
+	Resource              workv1alpha2.ObjectReference        `json:"resource"`
+	Policy                *objectReference                    `json:"policy,omitempty"`
+	Replicas              int32                               `json:"replicas,omitempty"`
+	Clusters              []workv1alpha2.TargetCluster        `json:"clusters"`
+	Scheduled             metav1.ConditionStatus              `json:"scheduled"`
+	FullyApplied          metav1.ConditionStatus              `json:"fullyApplied"`
+	Conditions            []condition                         `json:"conditions,omitempty"`
+	LastScheduledTime     *metav1.Time                        `json:"lastScheduledTime,omitempty"`
+	AggregatedStatus      []aggregatedStatus                  `json:"aggregatedStatus,omitempty"`
+	GracefulEvictionTasks []workv1alpha2.GracefulEvictionTask `json:"gracefulEvictionTasks,omitempty"`
+}
+
+// objectReference points to the Karmada object, such as the policy of a binding or the binding of a work, an object is derived from.
+type objectReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+type aggregatedStatus struct {
+	ClusterName    string                      `json:"clusterName"`
+	Applied        bool                        `json:"applied"`
+	AppliedMessage string                      `json:"appliedMessage,omitempty"`
+	Health         workv1alpha2.ResourceHealth `json:"health,omitempty"`
+	Status         json.RawMessage             `json:"status,omitempty"`
+}
+
+func newBindingSummary(objectMeta metav1.ObjectMeta, spec workv1alpha2.ResourceBindingSpec, status workv1alpha2.ResourceBindingStatus, withStatus bool) bindingSummary {
+	summary := bindingSummary{
+		Name:                  objectMeta.Name,
+		Namespace:             objectMeta.Namespace,
+		Resource:              spec.Resource,
+		Policy:                bindingPolicy(objectMeta.Annotations),
+		Replicas:              spec.Replicas,
+		Clusters:              spec.Clusters,
+		Scheduled:             metav1.ConditionUnknown,
+		FullyApplied:          metav1.ConditionUnknown,
+		Conditions:            newConditions(status.Conditions),
+		LastScheduledTime:     status.LastScheduledTime,
+		GracefulEvictionTasks: spec.GracefulEvictionTasks,
+	}
+	if cond := meta.FindStatusCondition(status.Conditions, workv1alpha2.Scheduled); cond != nil {
+		summary.Scheduled = cond.Status
+	}
+	if cond := meta.FindStatusCondition(status.Conditions, workv1alpha2.FullyApplied); cond != nil {
+		summary.FullyApplied = cond.Status
+	}
+	if summary.Clusters == nil {
+		summary.Clusters = []workv1alpha2.TargetCluster{}
+	}
+	for _, item := range status.AggregatedStatus {
+		s := aggregatedStatus{
+			ClusterName:    item.ClusterName,
+			Applied:        item.Applied,
+			AppliedMessage: item.AppliedMessage,
+			Health:         item.Health,
+		}
+		if withStatus && item.Status != nil {
+			s.Status = item.Status.Raw
+		}
+		summary.AggregatedStatus = append(summary.AggregatedStatus, s)
+	}
+	return summary
+}
+
+func bindingPolicy(annotations map[string]string) *objectReference {
+	if name := annotations[policyv1alpha1.PropagationPolicyNameAnnotation]; name != "" {
+		return &objectReference{
+			Kind:      "PropagationPolicy",
+			Namespace: annotations[policyv1alpha1.PropagationPolicyNamespaceAnnotation],
+			Name:      name,
+		}
+	}
+	if name := annotations[policyv1alpha1.ClusterPropagationPolicyAnnotation]; name != "" {
+		return &objectReference{Kind: "ClusterPropagationPolicy", Name: name}
+	}
+	return nil
+}
+
+// matchBindingResource reports whether the binding refers to the resource, empty kind or name matches any.
+func matchBindingResource(resource workv1alpha2.ObjectReference, kind, name string) bool {
+	return (kind == "" || resource.Kind == kind) && (name == "" || resource.Name == name)
+}
+
+// resourceBindingListResult is the result of list_resourcebinding.
+type resourceBindingListResult struct {
+	listPage
+	ResourceBindings []bindingSummary `json:"resourceBindings"`
+}
+
+func ListResourceBinding(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_resourcebinding",
+			mcp.WithDescription("List resourcebindings under the specific namespace in the Karmada control-plane with their scheduled clusters, replica assignments and Scheduled/FullyApplied conditions. Karmada creates one resourcebinding for every namespaced resource matched by a propagation policy. The result is paginated, pass nextCursor as cursor to get the next page"),
+			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			mcp.WithString("resourceKind", mcp.Description("only return the bindings of resources of this kind, e.g. Deployment")),
+			mcp.WithString("resourceName", mcp.Description("only return the bindings of resources with this name")),
+			withListQuery(),
+			withOutputSchema[resourceBindingListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+			paramKind, _ := request.GetArguments()["resourceKind"].(string)
+			paramResourceName, _ := request.GetArguments()["resourceName"].(string)
+
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := karmadaClient.WorkV1alpha2().ResourceBindings(paramNamespace).List(ctx, query.listOptions())
+			if err != nil {
+				klog.Errorf("failed to list resourcebindings, err: %v", err)
+				return nil, err
+			}
+			items := make([]*workv1alpha2.ResourceBinding, 0, len(resp.Items))
+			for i := range resp.Items {
+				if matchBindingResource(resp.Items[i].Spec.Resource, paramKind, paramResourceName) {
+					items = append(items, &resp.Items[i])
+				}
+			}
+			selected, page := selectObjects(items, query)
+			bindings := make([]bindingSummary, 0, len(selected))
+			for _, rb := range selected {
+				bindings = append(bindings, newBindingSummary(rb.ObjectMeta, rb.Spec, rb.Status, false))
+			}
+			return structuredResult(resourceBindingListResult{listPage: page, ResourceBindings: bindings})
+		}
+}
+
+func GetResourceBinding(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_resourcebinding",
+			mcp.WithDescription("Get a resourcebinding under the specific namespace in the Karmada control-plane, including the status aggregated from every member cluster"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of resourcebinding, which is <resource name>-<resource kind in lower case> for bindings created by Karmada")),
+			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withOutputSchema[bindingSummary](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			rb, err := karmadaClient.WorkV1alpha2().ResourceBindings(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get resourcebinding, err: %v", err)
+				return nil, err
+			}
+			return structuredResult(newBindingSummary(rb.ObjectMeta, rb.Spec, rb.Status, true))
+		}
+}
+
+// clusterResourceBindingListResult is the result of list_clusterresourcebinding.
+type clusterResourceBindingListResult struct {
+	listPage
+	ClusterResourceBindings []bindingSummary `json:"clusterResourceBindings"`
+}
+
+func ListClusterResourceBinding(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_clusterresourcebinding",
+			mcp.WithDescription("List clusterresourcebindings in the Karmada control-plane with their scheduled clusters, replica assignments and Scheduled/FullyApplied conditions. Karmada creates one clusterresourcebinding for every cluster-scoped resource matched by a clusterpropagationpolicy. The result is paginated, pass nextCursor as cursor to get the next page"),
+			mcp.WithString("resourceKind", mcp.Description("only return the bindings of resources of this kind, e.g. CustomResourceDefinition")),
+			mcp.WithString("resourceName", mcp.Description("only return the bindings of resources with this name")),
+			withListQuery(),
+			withOutputSchema[clusterResourceBindingListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramKind, _ := request.GetArguments()["resourceKind"].(string)
+			paramResourceName, _ := request.GetArguments()["resourceName"].(string)
+
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := karmadaClient.WorkV1alpha2().ClusterResourceBindings().List(ctx, query.listOptions())
+			if err != nil {
+				klog.Errorf("failed to list clusterresourcebindings, err: %v", err)
+				return nil, err
+			}
+			items := make([]*workv1alpha2.ClusterResourceBinding, 0, len(resp.Items))
+			for i := range resp.Items {
+				if matchBindingResource(resp.Items[i].Spec.Resource, paramKind, paramResourceName) {
+					items = append(items, &resp.Items[i])
+				}
+			}
+			selected, page := selectObjects(items, query)
+			bindings := make([]bindingSummary, 0, len(selected))
+			for _, crb := range selected {
+				bindings = append(bindings, newBindingSummary(crb.ObjectMeta, crb.Spec, crb.Status, false))
+			}
+			return structuredResult(clusterResourceBindingListResult{listPage: page, ClusterResourceBindings: bindings})
+		}
+}
+
+func GetClusterResourceBinding(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_clusterresourcebinding",
+			mcp.WithDescription("Get a clusterresourcebinding in the Karmada control-plane, including the status aggregated from every member cluster"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of clusterresourcebinding, which is <resource name>-<resource kind in lower case> for bindings created by Karmada")),
+			withOutputSchema[bindingSummary](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			crb, err := karmadaClient.WorkV1alpha2().ClusterResourceBindings().Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get clusterresourcebinding, err: %v", err)
+				return nil, err
+			}
+			return structuredResult(newBindingSummary(crb.ObjectMeta, crb.Spec, crb.Status, true))
+		}
+}
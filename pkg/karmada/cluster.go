@@ -4,42 +4,753 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/karmada-io/dashboard/pkg/dataselect"
-	"github.com/karmada-io/dashboard/pkg/resource/cluster"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"strings"
+	"time"
 )
 
+// clusterSummary is the condensed view of a member cluster returned by the cluster tools.
+type clusterSummary struct {
+	Name              string                                `json:"name"`
+	Ready             metav1.ConditionStatus                `json:"ready"`
+	Conditions        []condition                           `json:"conditions,omitempty"`
+	KubernetesVersion string                                `json:"kubernetesVersion,omitempty"`
+	SyncMode          clusterv1alpha1.ClusterSyncMode       `json:"syncMode"`
+	APIEndpoint       string                                `json:"apiEndpoint,omitempty"`
+	Provider          string                                `json:"provider,omitempty"`
+	Region            string                                `json:"region,omitempty"`
+	Zone              string                                `json:"zone,omitempty"`
+	Zones             []string                              `json:"zones,omitempty"`
+	Taints            []corev1.Taint                        `json:"taints,omitempty"`
+	Labels            map[string]string                     `json:"labels,omitempty"`
+	Nodes             *clusterv1alpha1.NodeSummary          `json:"nodes,omitempty"`
+	Resources         map[corev1.ResourceName]resourceUsage `json:"resources,omitempty"`
+}
+
+// condition is the compact form of metav1.Condition returned by the tools.
+type condition struct {
+	Type               string                 `json:"type"`
+	Status             metav1.ConditionStatus `json:"status"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime"`
+}
+
+type resourceUsage struct {
+	Allocatable string `json:"allocatable"`
+	Allocated   string `json:"allocated"`
+	Allocating  string `json:"allocating,omitempty"`
+}
+
+func newConditions(conditions []metav1.Condition) []condition {
+	result := make([]condition, 0, len(conditions))
+	for _, cond := range conditions {
+		result = append(result, condition{
+			Type:               cond.Type,
+			Status:             cond.Status,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime,
+		})
+	}
+	return result
+}
+
+// summarizedResources are the resources reported in the cluster resource summary.
+var summarizedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourcePods}
+
+func newClusterSummary(c *clusterv1alpha1.Cluster) clusterSummary {
+	summary := clusterSummary{
+		Name:              c.Name,
+		Ready:             metav1.ConditionUnknown,
+		KubernetesVersion: c.Status.KubernetesVersion,
+		SyncMode:          c.Spec.SyncMode,
+		APIEndpoint:       c.Spec.APIEndpoint,
+		Provider:          c.Spec.Provider,
+		Region:            c.Spec.Region,
+		Zone:              c.Spec.Zone,
+		Zones:             c.Spec.Zones,
+		Taints:            c.Spec.Taints,
+		Labels:            c.Labels,
+		Nodes:             c.Status.NodeSummary,
+	}
+	if ready := meta.FindStatusCondition(c.Status.Conditions, clusterv1alpha1.ClusterConditionReady); ready != nil {
+		summary.Ready = ready.Status
+	}
+	summary.Conditions = newConditions(c.Status.Conditions)
+	if rs := c.Status.ResourceSummary; rs != nil {
+		summary.Resources = make(map[corev1.ResourceName]resourceUsage, len(summarizedResources))
+		for _, name := range summarizedResources {
+			usage := resourceUsage{Allocatable: "0", Allocated: "0"}
+			if q, ok := rs.Allocatable[name]; ok {
+				usage.Allocatable = q.String()
+			}
+			if q, ok := rs.Allocated[name]; ok {
+				usage.Allocated = q.String()
+			}
+			if q, ok := rs.Allocating[name]; ok && !q.IsZero() {
+				usage.Allocating = q.String()
+			}
+			summary.Resources[name] = usage
+		}
+	}
+	return summary
+}
+
+// clusterListResult is the result of list_clusters.
+type clusterListResult struct {
+	listPage
+	Clusters []clusterSummary `json:"clusters"`
+}
+
 func ListClusters(getClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"list_clusters",
-			mcp.WithDescription("List all clusters in the Karmada control-plane."),
+			mcp.WithDescription("List the clusters in the Karmada control-plane with their readiness, Kubernetes version, sync mode, topology (provider/region/zone), taints, labels and resource summary. The result is paginated, pass nextCursor as cursor to get the next page."),
+			withListQuery(),
+			withOutputSchema[clusterListResult](),
 		),
 
-		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getClient(ctx)
-			ds := dataselect.NoDataSelect
 			if err != nil {
 				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
 			}
 
-			result, err := cluster.GetClusterList(karmadaClient, ds)
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := karmadaClient.ClusterV1alpha1().Clusters().List(ctx, query.listOptions())
 			if err != nil {
 				return nil, fmt.Errorf("failed to get cluster list: %w", err)
 			}
 
-			clusters := make([]string, 0)
-			for _, c := range result.Clusters {
-				clusters = append(clusters, c.ObjectMeta.Name)
+			items := make([]*clusterv1alpha1.Cluster, 0, len(result.Items))
+			for i := range result.Items {
+				items = append(items, &result.Items[i])
+			}
+			selected, page := selectObjects(items, query)
+			clusters := make([]clusterSummary, 0, len(selected))
+			for _, c := range selected {
+				clusters = append(clusters, newClusterSummary(c))
+			}
+
+			return structuredResult(clusterListResult{listPage: page, Clusters: clusters})
+		}
+}
+
+func GetCluster(getClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_cluster",
+			mcp.WithDescription("Get the readiness conditions, Kubernetes version, sync mode, topology (provider/region/zone), taints, labels and resource summary of a cluster in the Karmada control-plane."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the cluster")),
+			withOutputSchema[clusterSummary](),
+		),
+
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			c, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cluster %s: %w", paramName, err)
+			}
+
+			return structuredResult(newClusterSummary(c))
+		}
+}
+
+func JoinCluster(getKarmadaClient GetKarmadaClientFn, getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"join_cluster",
+			mcp.WithDescription("Register a Push-mode member cluster to the Karmada control-plane, the kubeconfig of the member cluster is read from a secret in the Karmada control-plane. A dry run reads the kubeconfig secret, checks the member cluster is reachable and not registered yet, and creates the cluster object with server-side dry-run, nothing is created in the member cluster"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the cluster to register")),
+			mcp.WithString("kubeconfigSecretNamespace", mcp.Required(), mcp.Description("namespace of the secret holding the member cluster kubeconfig")),
+			mcp.WithString("kubeconfigSecretName", mcp.Required(), mcp.Description("name of the secret holding the member cluster kubeconfig")),
+			mcp.WithString("kubeconfigSecretKey", mcp.DefaultString("kubeconfig"), mcp.Description("key of the kubeconfig in the secret data")),
+			mcp.WithString("kubeconfigContext", mcp.Description("context of the kubeconfig to use, defaults to the current context")),
+			mcp.WithString("clusterNamespace", mcp.DefaultString(defaultClusterNamespace), mcp.Description("namespace in the Karmada control-plane where the cluster credentials are stored")),
+			mcp.WithString("provider", mcp.Description("provider of the cluster")),
+			mcp.WithString("region", mcp.Description("region of the cluster")),
+			mcp.WithArray("zones", mcp.Items(map[string]interface{}{"type": "string"}), mcp.Description("zones of the cluster")),
+			withDryRun(),
+			withOutputSchema[writeResult[*clusterSummary]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			kubernetesClient, err := getKubernetesClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramSecretNamespace, ok := request.GetArguments()["kubeconfigSecretNamespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter kubeconfigSecretNamespace not found")
+			}
+
+			paramSecretName, ok := request.GetArguments()["kubeconfigSecretName"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter kubeconfigSecretName not found")
+			}
+
+			paramSecretKey := mcp.ParseString(request, "kubeconfigSecretKey", "kubeconfig")
+			paramContext, _ := request.GetArguments()["kubeconfigContext"].(string)
+			paramClusterNamespace := mcp.ParseString(request, "clusterNamespace", defaultClusterNamespace)
+			paramProvider, _ := request.GetArguments()["provider"].(string)
+			paramRegion, _ := request.GetArguments()["region"].(string)
+			paramZones, err := parseStringSlice(request, "zones")
+			if err != nil {
+				return nil, err
+			}
+			dryRun := parseDryRun(request)
+
+			secret, err := kubernetesClient.CoreV1().Secrets(paramSecretNamespace).Get(ctx, paramSecretName, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get kubeconfig secret %s/%s: %w", paramSecretNamespace, paramSecretName, err)
+			}
+			kubeconfig, ok := secret.Data[paramSecretKey]
+			if !ok {
+				return nil, fmt.Errorf("key %s not found in secret %s/%s", paramSecretKey, paramSecretNamespace, paramSecretName)
+			}
+
+			clusterConfig, err := restConfigFromKubeconfig(kubeconfig, paramContext)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load kubeconfig of cluster %s: %w", paramName, err)
+			}
+			clusterKubeClient, err := kubernetes.NewForConfig(clusterConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create client for cluster %s: %w", paramName, err)
+			}
+
+			joined, err := registerPushCluster(ctx, karmadaClient, kubernetesClient, clusterKubeClient, clusterRegisterOptions{
+				Name:      paramName,
+				Namespace: paramClusterNamespace,
+				Provider:  paramProvider,
+				Region:    paramRegion,
+				Zones:     paramZones,
+				Config:    clusterConfig,
+				DryRun:    dryRun,
+			})
+			if err != nil {
+				klog.ErrorS(err, "Failed to join cluster", "cluster", paramName)
+				return nil, fmt.Errorf("failed to join cluster %s: %w", paramName, err)
+			}
+			return clusterWriteResult(nil, joined, dryRun)
+		}
+}
+
+func UnjoinCluster(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"unjoin_cluster",
+			mcp.WithDescription("Remove a member cluster from the Karmada control-plane"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the cluster to remove")),
+			mcp.WithNumber("timeoutSeconds", mcp.DefaultNumber(60), mcp.Description("seconds to wait for the cluster object to be deleted, 0 means not waiting")),
+			withDryRun(),
+			withOutputSchema[writeResult[*clusterSummary]](),
+			withConfirmation(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+			paramTimeout := mcp.ParseInt(request, "timeoutSeconds", 60)
+
+			dryRun := parseDryRun(request)
+			if dryRun != nil {
+				live, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, paramName, metav1.GetOptions{})
+				if err != nil {
+					return nil, fmt.Errorf("failed to get cluster %s: %w", paramName, err)
+				}
+				if err = karmadaClient.ClusterV1alpha1().Clusters().Delete(ctx, paramName, metav1.DeleteOptions{DryRun: dryRun}); err != nil {
+					klog.ErrorS(err, "Failed to delete cluster", "cluster", paramName)
+					return nil, fmt.Errorf("failed to delete cluster %s: %w", paramName, err)
+				}
+				return dryRunResult(live, nil)
+			}
+
+			err = karmadaClient.ClusterV1alpha1().Clusters().Delete(ctx, paramName, metav1.DeleteOptions{})
+			if err != nil {
+				klog.ErrorS(err, "Failed to delete cluster", "cluster", paramName)
+				return nil, fmt.Errorf("failed to delete cluster %s: %w", paramName, err)
+			}
+			if paramTimeout <= 0 {
+				return structuredResult(writeResult[*clusterSummary]{Message: "unjoin cluster requested"})
 			}
 
-			r, err := json.Marshal(map[string]interface{}{
-				"clusters": clusters,
+			err = wait.PollUntilContextTimeout(ctx, time.Second, time.Duration(paramTimeout)*time.Second, true, func(ctx context.Context) (bool, error) {
+				_, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, paramName, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) {
+					return true, nil
+				}
+				return false, err
 			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal clusters: %w", err)
+				klog.ErrorS(err, "Wait for cluster deletion failed", "cluster", paramName)
+				return nil, fmt.Errorf("cluster %s is not deleted in %d seconds: %w", paramName, paramTimeout, err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(writeResult[*clusterSummary]{Message: "unjoin cluster success"})
+		}
+}
+
+func TaintCluster(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"taint_cluster",
+			mcp.WithDescription("Add a taint to a member cluster, or update the value of an existing taint with the same key and effect"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the cluster")),
+			mcp.WithString("key", mcp.Required(), mcp.Description("taint key, e.g. cluster.karmada.io/maintenance")),
+			mcp.WithString("value", mcp.Description("taint value")),
+			mcp.WithString("effect", mcp.Required(), mcp.Enum(string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectNoExecute)), mcp.Description("taint effect")),
+			withDryRun(),
+			withOutputSchema[writeResult[*clusterSummary]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+			paramKey, ok := request.GetArguments()["key"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter key not found")
+			}
+			paramValue, _ := request.GetArguments()["value"].(string)
+			paramEffect, ok := request.GetArguments()["effect"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter effect not found")
+			}
+
+			taint := corev1.Taint{Key: paramKey, Value: paramValue, Effect: corev1.TaintEffect(paramEffect)}
+			dryRun := parseDryRun(request)
+			live, c, err := updateClusterTaints(ctx, karmadaClient, paramName, dryRun, func(taints []corev1.Taint) []corev1.Taint {
+				for i := range taints {
+					if taints[i].MatchTaint(&taint) {
+						taints[i].Value = taint.Value
+						return taints
+					}
+				}
+				now := metav1.Now()
+				taint.TimeAdded = &now
+				return append(taints, taint)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to taint cluster %s: %w", paramName, err)
+			}
+
+			return clusterWriteResult(live, c, dryRun)
+		}
+}
+
+func UntaintCluster(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"untaint_cluster",
+			mcp.WithDescription("Remove taints from a member cluster"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the cluster")),
+			mcp.WithString("key", mcp.Required(), mcp.Description("taint key to remove")),
+			mcp.WithString("effect", mcp.Enum(string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectNoExecute)), mcp.Description("taint effect to remove, taints with any effect are removed if not specified")),
+			withDryRun(),
+			withOutputSchema[writeResult[*clusterSummary]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+			paramKey, ok := request.GetArguments()["key"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter key not found")
+			}
+			paramEffect, _ := request.GetArguments()["effect"].(string)
+
+			dryRun := parseDryRun(request)
+			live, c, err := updateClusterTaints(ctx, karmadaClient, paramName, dryRun, func(taints []corev1.Taint) []corev1.Taint {
+				remaining := make([]corev1.Taint, 0, len(taints))
+				for _, t := range taints {
+					if t.Key == paramKey && (paramEffect == "" || string(t.Effect) == paramEffect) {
+						continue
+					}
+					remaining = append(remaining, t)
+				}
+				return remaining
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to untaint cluster %s: %w", paramName, err)
+			}
+
+			return clusterWriteResult(live, c, dryRun)
+		}
+}
+
+func CordonCluster(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return newCordonTool(getKarmadaClient, "cordon_cluster", "Mark a member cluster as unschedulable, new workloads will not be scheduled to it", true)
+}
+
+func UncordonCluster(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return newCordonTool(getKarmadaClient, "uncordon_cluster", "Mark a member cluster as schedulable", false)
+}
+
+func newCordonTool(getKarmadaClient GetKarmadaClientFn, name, description string, cordon bool) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			name,
+			mcp.WithDescription(description),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the cluster")),
+			withDryRun(),
+			withOutputSchema[writeResult[*clusterSummary]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			unschedulable := corev1.Taint{Key: clusterv1alpha1.TaintClusterUnscheduler, Effect: corev1.TaintEffectNoSchedule}
+			dryRun := parseDryRun(request)
+			live, c, err := updateClusterTaints(ctx, karmadaClient, paramName, dryRun, func(taints []corev1.Taint) []corev1.Taint {
+				remaining := make([]corev1.Taint, 0, len(taints)+1)
+				cordoned := false
+				for _, t := range taints {
+					if !t.MatchTaint(&unschedulable) {
+						remaining = append(remaining, t)
+					} else if cordon {
+						// keep the taint of a cordoned cluster, so that cordoning it again changes nothing
+						remaining = append(remaining, t)
+						cordoned = true
+					}
+				}
+				if cordon && !cordoned {
+					now := metav1.Now()
+					unschedulable.TimeAdded = &now
+					remaining = append(remaining, unschedulable)
+				}
+				return remaining
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to %s cluster %s: %w", strings.TrimSuffix(name, "_cluster"), paramName, err)
+			}
+
+			return clusterWriteResult(live, c, dryRun)
+		}
+}
+
+// clusterWriteResult returns the summary of the cluster written, or for dry runs of the cluster the apiserver
+// would persist together with its diff against the live cluster, live is nil for joins.
+func clusterWriteResult(live, c *clusterv1alpha1.Cluster, dryRun []string) (*mcp.CallToolResult, error) {
+	summary := newClusterSummary(c)
+	if dryRun == nil {
+		return structuredResult(writeResult[*clusterSummary]{Object: &summary})
+	}
+	var before runtime.Object
+	if live != nil {
+		before = live
+	}
+	_, diff, err := dryRunDiff(before, c)
+	if err != nil {
+		return nil, err
+	}
+	return structuredResult(writeResult[*clusterSummary]{DryRun: true, Object: &summary, Diff: diff})
+}
+
+// updateClusterTaints replaces the taints of the cluster with the result of mutate, retrying on conflicts.
+// It returns the cluster before and after the update.
+func updateClusterTaints(ctx context.Context, karmadaClient karmadaclientset.Interface, name string, dryRun []string, mutate func([]corev1.Taint) []corev1.Taint) (*clusterv1alpha1.Cluster, *clusterv1alpha1.Cluster, error) {
+	var live, updated *clusterv1alpha1.Cluster
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		c, err := karmadaClient.ClusterV1alpha1().Clusters().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		live = c.DeepCopy()
+		taints := mutate(append([]corev1.Taint(nil), c.Spec.Taints...))
+		if equality.Semantic.DeepEqual(taints, c.Spec.Taints) {
+			updated = c
+			return nil
+		}
+		c.Spec.Taints = taints
+		updated, err = karmadaClient.ClusterV1alpha1().Clusters().Update(ctx, c, metav1.UpdateOptions{DryRun: dryRun})
+		return err
+	})
+	return live, updated, err
+}
+
+func restConfigFromKubeconfig(kubeconfig []byte, contextName string) (*rest.Config, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.NewNonInteractiveClientConfig(*config, contextName, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+}
+
+const (
+	// defaultClusterNamespace is the namespace where karmadactl stores the cluster credentials.
+	defaultClusterNamespace = "karmada-cluster"
+	karmadaSystemLabel      = "karmada.io/system"
+)
+
+type clusterRegisterOptions struct {
+	Name      string
+	Namespace string
+	Provider  string
+	Region    string
+	Zones     []string
+	Config    *rest.Config
+	// DryRun only checks the member cluster and creates the cluster object with server-side dry-run.
+	DryRun []string
+}
+
+// registerPushCluster mirrors `karmadactl join`: it creates the service accounts Karmada uses to access
+// the member cluster, copies their tokens into the control-plane and creates the Push-mode Cluster object.
+func registerPushCluster(ctx context.Context, karmadaClient karmadaclientset.Interface, kubernetesClient, clusterKubeClient kubernetes.Interface, opts clusterRegisterOptions) (*clusterv1alpha1.Cluster, error) {
+	systemNamespace, err := clusterKubeClient.CoreV1().Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain cluster id: %w", err)
+	}
+	clusterID := string(systemNamespace.UID)
+	registered, err := karmadaClient.ClusterV1alpha1().Clusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range registered.Items {
+		if c.Spec.ID == clusterID {
+			return nil, fmt.Errorf("the same cluster has been registered with name %s", c.Name)
+		}
+	}
+
+	clusterObj := &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: opts.Name},
+		Spec: clusterv1alpha1.ClusterSpec{
+			ID:                          clusterID,
+			SyncMode:                    clusterv1alpha1.Push,
+			APIEndpoint:                 opts.Config.Host,
+			InsecureSkipTLSVerification: opts.Config.TLSClientConfig.Insecure,
+			Provider:                    opts.Provider,
+			Region:                      opts.Region,
+			Zones:                       opts.Zones,
+			SecretRef:                   &clusterv1alpha1.LocalSecretReference{Namespace: opts.Namespace, Name: opts.Name},
+			ImpersonatorSecretRef:       &clusterv1alpha1.LocalSecretReference{Namespace: opts.Namespace, Name: fmt.Sprintf("%s-impersonator", opts.Name)},
+		},
+	}
+	if opts.Config.Proxy != nil {
+		proxyURL, err := opts.Config.Proxy(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve proxy: %w", err)
+		}
+		if proxyURL != nil {
+			clusterObj.Spec.ProxyURL = proxyURL.String()
+		}
+	}
+	if opts.DryRun != nil {
+		return karmadaClient.ClusterV1alpha1().Clusters().Create(ctx, clusterObj, metav1.CreateOptions{DryRun: opts.DryRun})
+	}
+
+	labels := map[string]string{karmadaSystemLabel: "true"}
+	if err = ensureNamespace(ctx, clusterKubeClient, opts.Namespace, labels); err != nil {
+		return nil, err
+	}
+	impersonatorToken, err := ensureServiceAccountToken(ctx, clusterKubeClient, opts.Namespace, "karmada-impersonator", labels)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccountName := fmt.Sprintf("karmada-%s", opts.Name)
+	roleName := fmt.Sprintf("karmada-controller-manager:%s", serviceAccountName)
+	if err = ensureClusterAdminBinding(ctx, clusterKubeClient, roleName, opts.Namespace, serviceAccountName, labels); err != nil {
+		return nil, err
+	}
+	clusterToken, err := ensureServiceAccountToken(ctx, clusterKubeClient, opts.Namespace, serviceAccountName, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureNamespace(ctx, kubernetesClient, opts.Namespace, labels); err != nil {
+		return nil, err
+	}
+	impersonatorSecret, err := createOrUpdateSecret(ctx, kubernetesClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: opts.Namespace, Name: clusterObj.Spec.ImpersonatorSecretRef.Name, Labels: labels},
+		Data: map[string][]byte{
+			clusterv1alpha1.SecretTokenKey: impersonatorToken.Data[clusterv1alpha1.SecretTokenKey],
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create impersonator secret in control plane: %w", err)
+	}
+	clusterSecret, err := createOrUpdateSecret(ctx, kubernetesClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: opts.Namespace, Name: clusterObj.Spec.SecretRef.Name, Labels: labels},
+		Data: map[string][]byte{
+			clusterv1alpha1.SecretCADataKey: clusterToken.Data[corev1.ServiceAccountRootCAKey],
+			clusterv1alpha1.SecretTokenKey:  clusterToken.Data[clusterv1alpha1.SecretTokenKey],
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret in control plane: %w", err)
+	}
+
+	joined, err := karmadaClient.ClusterV1alpha1().Clusters().Create(ctx, clusterObj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster object: %w", err)
+	}
+
+	// let the credentials be garbage collected together with the cluster
+	ownerPatch, err := json.Marshal(metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(joined, clusterv1alpha1.SchemeGroupVersion.WithKind("Cluster")),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{clusterSecret.Name, impersonatorSecret.Name} {
+		if _, err = kubernetesClient.CoreV1().Secrets(opts.Namespace).Patch(ctx, name, types.MergePatchType, ownerPatch, metav1.PatchOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to patch secret %s/%s: %w", opts.Namespace, name, err)
+		}
+	}
+	return joined, nil
+}
+
+func ensureNamespace(ctx context.Context, client kubernetes.Interface, name string, labels map[string]string) error {
+	_, err := client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// createOrUpdateSecret creates the secret, or updates the data of the existing one like karmadactl, so that
+// a cluster can be joined again after a failed join or an unjoin leaving its secrets behind.
+func createOrUpdateSecret(ctx context.Context, client kubernetes.Interface, secret *corev1.Secret) (*corev1.Secret, error) {
+	created, err := client.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err == nil {
+		return created, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	var updated *corev1.Secret
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := client.CoreV1().Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existing.Labels = mergeStringMap(existing.Labels, secret.Labels)
+		existing.Data = secret.Data
+		updated, err = client.CoreV1().Secrets(secret.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+	return updated, err
+}
+
+func ensureClusterAdminBinding(ctx context.Context, client kubernetes.Interface, roleName, namespace, serviceAccountName string, labels map[string]string) error {
+	_, err := client.RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: roleName, Labels: labels},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{rbacv1.APIGroupAll}, Resources: []string{rbacv1.ResourceAll}, Verbs: []string{rbacv1.VerbAll}},
+			{NonResourceURLs: []string{rbacv1.NonResourceAll}, Verbs: []string{"get"}},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create cluster role %s: %w", roleName, err)
+	}
+	_, err = client.RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: roleName, Labels: labels},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: serviceAccountName, Namespace: namespace}},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: roleName},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create cluster role binding %s: %w", roleName, err)
+	}
+	return nil
+}
+
+// ensureServiceAccountToken creates the service account and waits for its long-lived token secret to be populated.
+func ensureServiceAccountToken(ctx context.Context, client kubernetes.Interface, namespace, name string, labels map[string]string) (*corev1.Secret, error) {
+	_, err := client.CoreV1().ServiceAccounts(namespace).Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create service account %s/%s: %w", namespace, name, err)
+	}
+	_, err = client.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{corev1.ServiceAccountNameKey: name},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create token secret %s/%s: %w", namespace, name, err)
+	}
+
+	var secret *corev1.Secret
+	err = wait.PollUntilContextTimeout(ctx, time.Second, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+		secret, err = client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
 		}
+		// wait for the token controller to populate the token
+		_, ok := secret.Data[corev1.ServiceAccountTokenKey]
+		return ok, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token of service account %s/%s: %w", namespace, name, err)
+	}
+	return secret, nil
 }
@@ -0,0 +1,214 @@
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+func CreateClusterOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"create_clusteroverridepolicy",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Create a clusteroverridepolicy resources in the Karmada control-plane, clusteroverridepolicy is cluster-scoped and customizes resources of any namespace per member cluster"),
+				mcp.WithString("name", mcp.Required(), mcp.Description("name for clusteroverridepolicy")),
+				withDryRun(),
+				withOutputSchema[writeResult[*v1alpha1.ClusterOverridePolicy]](),
+			}, overriderToolOptions()...)...,
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			spec, _, err := buildOverrideSpec(request, v1alpha1.OverrideSpec{})
+			if err != nil {
+				return nil, err
+			}
+			clusterOverridePolicy := v1alpha1.ClusterOverridePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: paramName},
+				Spec:       spec,
+			}
+
+			dryRun := parseDryRun(request)
+			createResp, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Create(ctx, &clusterOverridePolicy, metav1.CreateOptions{DryRun: dryRun})
+			if err != nil {
+				klog.Errorf("create clusteroverridepolicy error: %v", err)
+				return nil, err
+			}
+			if dryRun != nil {
+				return dryRunResult(nil, createResp)
+			}
+
+			return structuredResult(writeResult[*v1alpha1.ClusterOverridePolicy]{Object: createResp})
+		}
+}
+
+func UpdateClusterOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"update_clusteroverridepolicy",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Update an existing clusteroverridepolicy in the Karmada control-plane without deleting it, returns the updated clusteroverridepolicy and the diff of its spec. The spec of content replaces the current one and its labels and annotations are added to the current ones, the other overrider arguments are appended as an extra override rule to the spec of content, or to the current spec without content"),
+				mcp.WithString("name", mcp.Required(), mcp.Description("name for clusteroverridepolicy")),
+				mcp.WithString("resourceVersion", mcp.Description("resourceVersion the update is based on, the update fails if the clusteroverridepolicy has been changed since, overrides metadata.resourceVersion of the content. If both are empty the latest version is updated")),
+				withDryRun(),
+				withOutputSchema[writeResult[*v1alpha1.ClusterOverridePolicy]](),
+			}, overriderToolOptions()...)...,
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramResourceVersion, _ := request.GetArguments()["resourceVersion"].(string)
+
+			current, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("get clusteroverridepolicy error: %v", err)
+				return nil, err
+			}
+			spec, objectMeta, err := buildOverrideSpec(request, current.Spec)
+			if err != nil {
+				return nil, err
+			}
+			updated := mergePolicyUpdate(current, &v1alpha1.ClusterOverridePolicy{ObjectMeta: objectMeta}, paramResourceVersion)
+			updated.Spec = spec
+
+			dryRun := parseDryRun(request)
+			return updatePolicy("clusteroverridepolicy", current, updated, dryRun,
+				func(policy *v1alpha1.ClusterOverridePolicy) (*v1alpha1.ClusterOverridePolicy, error) {
+					return karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Update(ctx, policy, metav1.UpdateOptions{DryRun: dryRun})
+				},
+				func(policy *v1alpha1.ClusterOverridePolicy) interface{} { return policy.Spec },
+			)
+		}
+}
+
+// clusterOverridePolicyListResult is the result of list_clusteroverridepolicy.
+type clusterOverridePolicyListResult struct {
+	listPage
+	ClusterOverridePolicies []string `json:"clusterOverridePolicies"`
+}
+
+func ListClusterOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_clusteroverridepolicy",
+			mcp.WithDescription("List clusteroverridepolicies in the Karmada control-plane. The result is paginated, pass nextCursor as cursor to get the next page"),
+			withListQuery(),
+			withOutputSchema[clusterOverridePolicyListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().List(ctx, query.listOptions())
+			if err != nil {
+				klog.Errorf("failed to list clusteroverridepolicies, err: %v", err)
+				return nil, err
+			}
+			items := make([]*v1alpha1.ClusterOverridePolicy, 0, len(resp.Items))
+			for i := range resp.Items {
+				items = append(items, &resp.Items[i])
+			}
+			selected, page := selectObjects(items, query)
+			clusterOverridePolicyList := make([]string, 0, len(selected))
+			for _, clusterOverridePolicy := range selected {
+				clusterOverridePolicyList = append(clusterOverridePolicyList, clusterOverridePolicy.Name)
+			}
+			return structuredResult(clusterOverridePolicyListResult{listPage: page, ClusterOverridePolicies: clusterOverridePolicyList})
+		}
+}
+
+func GetClusterOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_clusteroverridepolicy",
+			mcp.WithDescription("Get clusteroverridepolicy detailed yaml in the Karmada control-plane"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for clusteroverridepolicy")),
+			withOutputSchema[v1alpha1.ClusterOverridePolicy](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			resp, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get clusteroverridepolicy, err: %v", err)
+				return nil, err
+			}
+			return structuredResult(resp)
+		}
+}
+
+func DeleteClusterOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"delete_clusteroverridepolicy",
+			mcp.WithDescription("Delete clusteroverridepolicy in the Karmada control-plane"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for clusteroverridepolicy")),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.ClusterOverridePolicy]](),
+			withConfirmation(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			dryRun := parseDryRun(request)
+			if dryRun != nil {
+				live, err := karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Get(ctx, paramName, metav1.GetOptions{})
+				if err != nil {
+					klog.Errorf("get clusteroverridepolicy error: %v", err)
+					return nil, err
+				}
+				if err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Delete(ctx, paramName, metav1.DeleteOptions{DryRun: dryRun}); err != nil {
+					klog.ErrorS(err, "Failed to delete clusteroverridepolicy")
+					return nil, err
+				}
+				return dryRunResult(live, nil)
+			}
+
+			err = karmadaClient.PolicyV1alpha1().ClusterOverridePolicies().Delete(ctx, paramName, metav1.DeleteOptions{})
+			if err != nil {
+				klog.ErrorS(err, "Failed to delete clusteroverridepolicy")
+				return nil, err
+			}
+
+			return structuredResult(writeResult[*v1alpha1.ClusterOverridePolicy]{Message: "delete clusteroverridepolicy success"})
+		}
+}
@@ -0,0 +1,237 @@
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"github.com/karmada-io/dashboard/pkg/resource/clusterpropagationpolicy"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+func CreateClusterPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"create_clusterpropagationpolicy",
+			mcp.WithDescription("Create a clusterpropagationpolicy resources in the Karmada control-plane, clusterpropagationpolicy is cluster-scoped and can propagate cluster-scoped resources such as CRDs and namespaces"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for clusterpropagationpolicy")),
+			mcp.WithString("content", mcp.Required(), mcp.Description(`clusterpropagationpolicy content which in form of yaml, one clusterpropagationpolicy yaml file likes:
+apiVersion: policy.karmada.io/v1alpha1
+kind: ClusterPropagationPolicy
+metadata:
+  name: foo-crd-propagation
+spec:
+  resourceSelectors:
+    - apiVersion: apiextensions.k8s.io/v1
+      kind: CustomResourceDefinition
+      name: foos.example.io
+  placement:
+    clusterAffinity:
+      clusterNames:
+        - member1
+        - member2
+`)),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.ClusterPropagationPolicy]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramContent, ok := request.GetArguments()["content"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter content not found")
+			}
+			clusterPropagationPolicy := v1alpha1.ClusterPropagationPolicy{}
+			if err = yaml.Unmarshal([]byte(paramContent), &clusterPropagationPolicy); err != nil {
+				klog.Errorf("unmarshal clusterpropagationpolicy error: %v", err)
+				return nil, err
+			}
+			clusterPropagationPolicy.Name = paramName
+
+			dryRun := parseDryRun(request)
+			createResp, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(ctx, &clusterPropagationPolicy, metav1.CreateOptions{DryRun: dryRun})
+			if err != nil {
+				klog.Errorf("create clusterpropagationpolicy error: %v", err)
+				return nil, err
+			}
+			if dryRun != nil {
+				return dryRunResult(nil, createResp)
+			}
+
+			return structuredResult(writeResult[*v1alpha1.ClusterPropagationPolicy]{Object: createResp})
+		}
+}
+
+func UpdateClusterPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"update_clusterpropagationpolicy",
+			mcp.WithDescription("Replace the spec of an existing clusterpropagationpolicy in the Karmada control-plane without deleting it, returns the updated clusterpropagationpolicy and the diff of its spec"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for clusterpropagationpolicy")),
+			mcp.WithString("content", mcp.Required(), mcp.Description("the clusterpropagationpolicy content which in form of yaml, its spec replaces the current one and its labels and annotations are added to the current ones")),
+			mcp.WithString("resourceVersion", mcp.Description("resourceVersion the content is based on, the update fails if the clusterpropagationpolicy has been changed since, overrides metadata.resourceVersion of the content. If both are empty the latest version is replaced")),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.ClusterPropagationPolicy]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramContent, ok := request.GetArguments()["content"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter content not found")
+			}
+			paramResourceVersion, _ := request.GetArguments()["resourceVersion"].(string)
+
+			desired := v1alpha1.ClusterPropagationPolicy{}
+			if err = yaml.Unmarshal([]byte(paramContent), &desired); err != nil {
+				klog.Errorf("unmarshal clusterpropagationpolicy error: %v", err)
+				return nil, err
+			}
+
+			current, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("get clusterpropagationpolicy error: %v", err)
+				return nil, err
+			}
+			updated := mergePolicyUpdate(current, &desired, paramResourceVersion)
+			updated.Spec = desired.Spec
+
+			dryRun := parseDryRun(request)
+			return updatePolicy("clusterpropagationpolicy", current, updated, dryRun,
+				func(policy *v1alpha1.ClusterPropagationPolicy) (*v1alpha1.ClusterPropagationPolicy, error) {
+					return karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Update(ctx, policy, metav1.UpdateOptions{DryRun: dryRun})
+				},
+				func(policy *v1alpha1.ClusterPropagationPolicy) interface{} { return policy.Spec },
+			)
+		}
+}
+
+// clusterPropagationPolicyListResult is the result of list_clusterpropagationpolicy.
+type clusterPropagationPolicyListResult struct {
+	listPage
+	ClusterPropagationPolicies []string `json:"clusterPropagationPolicies"`
+}
+
+func ListClusterPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_clusterpropagationpolicy",
+			mcp.WithDescription("List clusterpropagationpolicies in the Karmada control-plane. The result is paginated, pass nextCursor as cursor to get the next page"),
+			withListQuery(),
+			withOutputSchema[clusterPropagationPolicyListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, query.listOptions())
+			if err != nil {
+				klog.Errorf("failed to list clusterpropagationpolicies, err: %v", err)
+				return nil, err
+			}
+			items := make([]*v1alpha1.ClusterPropagationPolicy, 0, len(resp.Items))
+			for i := range resp.Items {
+				items = append(items, &resp.Items[i])
+			}
+			selected, page := selectObjects(items, query)
+			clusterPropagationPolicyList := make([]string, 0, len(selected))
+			for _, clusterPropagationPolicy := range selected {
+				clusterPropagationPolicyList = append(clusterPropagationPolicyList, clusterPropagationPolicy.Name)
+			}
+			return structuredResult(clusterPropagationPolicyListResult{listPage: page, ClusterPropagationPolicies: clusterPropagationPolicyList})
+		}
+}
+
+func GetClusterPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_clusterpropagationpolicy",
+			mcp.WithDescription("Get clusterpropagationpolicy detailed yaml in the Karmada control-plane"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for clusterpropagationpolicy")),
+			withOutputSchema[clusterpropagationpolicy.ClusterPropagationPolicyDetail](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			resp, err := clusterpropagationpolicy.GetClusterPropagationPolicyDetail(karmadaClient, paramName)
+			if err != nil {
+				klog.Errorf("failed to get clusterpropagationpolicy, err: %v", err)
+				return nil, err
+			}
+			return structuredResult(resp)
+		}
+}
+
+func DeleteClusterPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"delete_clusterpropagationpolicy",
+			mcp.WithDescription("Delete clusterpropagationpolicy in the Karmada control-plane"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for clusterpropagationpolicy")),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.ClusterPropagationPolicy]](),
+			withConfirmation(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			dryRun := parseDryRun(request)
+			if dryRun != nil {
+				live, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Get(ctx, paramName, metav1.GetOptions{})
+				if err != nil {
+					klog.Errorf("get clusterpropagationpolicy error: %v", err)
+					return nil, err
+				}
+				if err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Delete(ctx, paramName, metav1.DeleteOptions{DryRun: dryRun}); err != nil {
+					klog.ErrorS(err, "Failed to delete clusterpropagationpolicy")
+					return nil, err
+				}
+				return dryRunResult(live, nil)
+			}
+
+			err = karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Delete(ctx, paramName, metav1.DeleteOptions{})
+			if err != nil {
+				klog.ErrorS(err, "Failed to delete clusterpropagationpolicy")
+				return nil, err
+			}
+
+			return structuredResult(writeResult[*v1alpha1.ClusterPropagationPolicy]{Message: "delete clusterpropagationpolicy success"})
+		}
+}
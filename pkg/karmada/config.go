@@ -1,5 +1,10 @@
 package karmada
 
+import (
+	"github.com/warjiang/karmada-mcp-server/pkg/audit"
+	"time"
+)
+
 type MCPServerConfig struct {
 	// Version of the server
 	Version string
@@ -10,4 +15,19 @@ type MCPServerConfig struct {
 
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
+
+	// DynamicToolsets starts the server with the meta-tools enabling toolsets on demand, "all" is ignored in EnabledToolsets
+	DynamicToolsets bool
+
+	// ToolPolicyFile is a YAML file allowing or denying tool calls, see toolpolicy.Policy
+	ToolPolicyFile string
+
+	// Audit configures the audit log of tool calls
+	Audit audit.Options
+
+	// Confirmation selects how the calls of destructive tools are confirmed, defaults to ConfirmationNone
+	Confirmation ConfirmationMode
+
+	// ConfirmationTTL is how long a confirmation token is valid, defaults to 5 minutes
+	ConfirmationTTL time.Duration
 }
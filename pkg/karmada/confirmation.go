@@ -0,0 +1,227 @@
+package karmada
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+	"sync"
+	"time"
+)
+
+// ConfirmationMode selects how the calls of destructive tools are confirmed.
+type ConfirmationMode string
+
+const (
+	// ConfirmationAuto asks the user via elicitation if the client supports it, otherwise requires a confirmation token
+	ConfirmationAuto ConfirmationMode = "auto"
+	// ConfirmationToken always requires a confirmation token
+	ConfirmationToken ConfirmationMode = "token"
+	// ConfirmationNone executes destructive tools immediately
+	ConfirmationNone ConfirmationMode = "none"
+)
+
+const confirmationTokenParam = "confirmationToken"
+
+// withConfirmation marks a tool as destructive, its calls are only executed once confirmed.
+func withConfirmation() mcp.ToolOption {
+	return mcp.WithString(confirmationTokenParam,
+		mcp.Description("token returned by a previous call of this tool with the same arguments, if the server "+
+			"requires confirmation destructive calls are not executed until they are repeated with the token before it expires"))
+}
+
+// confirmation is a planned call of a destructive tool waiting to be repeated with its token.
+type confirmation struct {
+	sessionID string
+	tool      string
+	digest    string
+	expiresAt time.Time
+}
+
+// confirmer holds the destructive calls until they are confirmed, either by the user answering an elicitation
+// request or by the client repeating the call with the token returned by the first call, so that a destructive
+// call can never be executed in a single step.
+type confirmer struct {
+	mode ConfirmationMode
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	pending map[string]confirmation
+}
+
+func newConfirmer(mode ConfirmationMode, ttl time.Duration) *confirmer {
+	return &confirmer{
+		mode:    mode,
+		ttl:     ttl,
+		pending: map[string]confirmation{},
+	}
+}
+
+func (c *confirmer) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// dry runs do not change anything, they are what a user looks at before confirming
+		if !isDestructive(ctx, request.Params.Name) || parseDryRun(request) != nil {
+			return next(ctx, request)
+		}
+
+		if c.mode == ConfirmationAuto && supportsElicitation(ctx) {
+			confirmed, err := c.elicit(ctx, request)
+			if err != nil {
+				klog.Errorf("failed to ask for confirmation of tool %s, err: %v", request.Params.Name, err)
+				return mcp.NewToolResultError(fmt.Sprintf("failed to ask the user to confirm %s: %v", request.Params.Name, err)), nil
+			}
+			if !confirmed {
+				return mcp.NewToolResultError(fmt.Sprintf("the user did not confirm %s, nothing was changed", request.Params.Name)), nil
+			}
+			return next(ctx, request)
+		}
+
+		if token, _ := request.GetArguments()[confirmationTokenParam].(string); token != "" {
+			if err := c.redeem(ctx, token, request); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return next(ctx, request)
+		}
+		return c.plan(ctx, request)
+	}
+}
+
+// isDestructive reports whether the tool was registered withConfirmation.
+func isDestructive(ctx context.Context, name string) bool {
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return false
+	}
+	tool := s.GetTool(name)
+	if tool == nil {
+		return false
+	}
+	_, ok := tool.Tool.InputSchema.Properties[confirmationTokenParam]
+	return ok
+}
+
+func supportsElicitation(ctx context.Context) bool {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		return false
+	}
+	if _, ok := session.(server.SessionWithElicitation); !ok {
+		return false
+	}
+	return session.GetClientCapabilities().Elicitation != nil
+}
+
+func (c *confirmer) elicit(ctx context.Context, request mcp.CallToolRequest) (bool, error) {
+	arguments, err := json.MarshalIndent(callArguments(request), "", "  ")
+	if err != nil {
+		return false, err
+	}
+	result, err := server.ServerFromContext(ctx).RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: fmt.Sprintf("The assistant wants to run the destructive tool %s with the arguments:\n%s\nDo you want to continue?", request.Params.Name, arguments),
+			RequestedSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"description": fmt.Sprintf("run %s", request.Params.Name),
+					},
+				},
+				"required": []string{"confirm"},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return false, nil
+	}
+	content, _ := result.Content.(map[string]interface{})
+	return content["confirm"] == true, nil
+}
+
+// plan remembers the call and returns the token it must be repeated with.
+func (c *confirmer) plan(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	digest, err := callDigest(request)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+	expiresAt := time.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	for t, pending := range c.pending {
+		if time.Now().After(pending.expiresAt) {
+			delete(c.pending, t)
+		}
+	}
+	c.pending[token] = confirmation{
+		sessionID: sessionID(ctx),
+		tool:      request.Params.Name,
+		digest:    digest,
+		expiresAt: expiresAt,
+	}
+	c.mu.Unlock()
+
+	// the plan is structured content matching the output schema of the write tools, see writeResult
+	return structuredResult(map[string]interface{}{
+		"confirmationRequired": true,
+		"tool":                 request.Params.Name,
+		"arguments":            callArguments(request),
+		confirmationTokenParam: token,
+		"expiresAt":            expiresAt.UTC().Format(time.RFC3339),
+		"message": fmt.Sprintf("%s is destructive and was not executed. Show the user what will be changed and ask for confirmation, "+
+			"then call %s again with the same arguments and %s set to this token before it expires.", request.Params.Name, request.Params.Name, confirmationTokenParam),
+	})
+}
+
+// redeem consumes the token if it was issued for the same call in the same session and has not expired.
+func (c *confirmer) redeem(ctx context.Context, token string, request mcp.CallToolRequest) error {
+	digest, err := callDigest(request)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending, ok := c.pending[token]
+	if !ok || time.Now().After(pending.expiresAt) {
+		delete(c.pending, token)
+		return fmt.Errorf("confirmation token of %s is unknown or expired, call %s without %s to get a new one", request.Params.Name, request.Params.Name, confirmationTokenParam)
+	}
+	if pending.sessionID != sessionID(ctx) || pending.tool != request.Params.Name || pending.digest != digest {
+		return fmt.Errorf("confirmation token was issued for a different call, the arguments of %s must not change between the calls", request.Params.Name)
+	}
+	delete(c.pending, token)
+	return nil
+}
+
+// callArguments returns the arguments of the call without the confirmation token.
+func callArguments(request mcp.CallToolRequest) map[string]interface{} {
+	arguments := map[string]interface{}{}
+	for name, value := range request.GetArguments() {
+		if name != confirmationTokenParam {
+			arguments[name] = value
+		}
+	}
+	return arguments
+}
+
+func callDigest(request mcp.CallToolRequest) (string, error) {
+	// map keys are marshalled in sorted order, so equal arguments have equal digests
+	b, err := json.Marshal(callArguments(request))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
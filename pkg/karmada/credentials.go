@@ -0,0 +1,107 @@
+package karmada
+
+import (
+	"context"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Credentials is the identity of the MCP client a tool call acts with against the Karmada apiserver.
+type Credentials struct {
+	// BearerToken replaces the credentials of the server when set, the apiserver authenticates the caller itself
+	BearerToken string
+
+	// Impersonate is the user, groups and extra fields the request is impersonating
+	Impersonate rest.ImpersonationConfig
+}
+
+type credentialsKey struct{}
+
+// WithCredentials returns a copy of ctx carrying the credentials, the clients handed to the tools
+// for this context are built with them instead of the identity of the server.
+func WithCredentials(ctx context.Context, credentials *Credentials) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, credentials)
+}
+
+// CredentialsFromContext returns the credentials stored in ctx, nil if the call should use the identity of the server.
+func CredentialsFromContext(ctx context.Context) *Credentials {
+	credentials, _ := ctx.Value(credentialsKey{}).(*Credentials)
+	return credentials
+}
+
+// NewHTTPContextFunc returns a function for the sse and streamable http transports which stores the
+// credentials forwarded by the MCP client in the request context.
+//
+// A bearer token in the Authorization header is forwarded as is, together with the Impersonate-* headers,
+// so the apiserver authenticates the caller and authorizes the impersonation against its own RBAC.
+// Impersonate-* headers without a bearer token are only honored if trustImpersonationHeaders is set, the
+// server then impersonates with its own identity, which is only safe behind a proxy that authenticates
+// clients and sets these headers itself.
+func NewHTTPContextFunc(trustImpersonationHeaders bool) func(context.Context, *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		credentials := &Credentials{}
+		if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+			credentials.BearerToken = strings.TrimSpace(token)
+		}
+		if credentials.BearerToken != "" || trustImpersonationHeaders {
+			credentials.Impersonate = impersonationFromHeader(r.Header)
+		}
+		if credentials.BearerToken == "" && credentials.Impersonate.UserName == "" {
+			return ctx
+		}
+		return WithCredentials(ctx, credentials)
+	}
+}
+
+func impersonationFromHeader(header http.Header) rest.ImpersonationConfig {
+	impersonate := rest.ImpersonationConfig{
+		UserName: header.Get(authenticationv1.ImpersonateUserHeader),
+		UID:      header.Get(authenticationv1.ImpersonateUIDHeader),
+		Groups:   header.Values(authenticationv1.ImpersonateGroupHeader),
+	}
+	if impersonate.UserName == "" {
+		return rest.ImpersonationConfig{}
+	}
+	for name, values := range header {
+		if !strings.HasPrefix(name, authenticationv1.ImpersonateUserExtraHeaderPrefix) {
+			continue
+		}
+		if impersonate.Extra == nil {
+			impersonate.Extra = map[string][]string{}
+		}
+		// Extra keys are percent-encoded in header names, see the impersonation docs of Kubernetes
+		key := strings.ToLower(strings.TrimPrefix(name, authenticationv1.ImpersonateUserExtraHeaderPrefix))
+		if unescaped, err := url.PathUnescape(key); err == nil {
+			key = unescaped
+		}
+		impersonate.Extra[key] = append(impersonate.Extra[key], values...)
+	}
+	return impersonate
+}
+
+// configForContext returns a copy of config acting with the credentials stored in ctx,
+// ok is false if there are none and the shared clients of the server should be used.
+func configForContext(ctx context.Context, config *rest.Config) (*rest.Config, bool) {
+	credentials := CredentialsFromContext(ctx)
+	if credentials == nil {
+		return nil, false
+	}
+	config = rest.CopyConfig(config)
+	if credentials.BearerToken != "" {
+		config.BearerToken = credentials.BearerToken
+		config.BearerTokenFile = ""
+		config.Username = ""
+		config.Password = ""
+		config.AuthProvider = nil
+		config.ExecProvider = nil
+		config.TLSClientConfig.CertFile = ""
+		config.TLSClientConfig.CertData = nil
+		config.TLSClientConfig.KeyFile = ""
+		config.TLSClientConfig.KeyData = nil
+	}
+	config.Impersonate = credentials.Impersonate
+	return config, true
+}
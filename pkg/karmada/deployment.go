@@ -2,11 +2,7 @@ package karmada
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"github.com/karmada-io/dashboard/pkg/dataselect"
-	"github.com/karmada-io/dashboard/pkg/resource/common"
-	"github.com/karmada-io/dashboard/pkg/resource/deployment"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	appsv1 "k8s.io/api/apps/v1"
@@ -22,7 +18,8 @@ func CreateDeployment(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool,
 			mcp.WithString("name", mcp.Required(), mcp.Description("name for deployment")),
 			mcp.WithString("namespace", mcp.Required(), mcp.Description("namespace for deployment")),
 			mcp.WithString("content", mcp.Required(), mcp.Description("deployment content which in form of yaml")),
-			//mcp.WithBoolean("dry-run", mcp.DefaultBool(true), mcp.Description("dry-run options, if true the resource will not be applied to apiserver directly")),
+			withDryRun(),
+			withOutputSchema[writeResult[*appsv1.Deployment]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKubernetesClient(ctx)
@@ -30,17 +27,17 @@ func CreateDeployment(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool,
 				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
 			}
 
-			paramName, ok := request.Params.Arguments["name"].(string)
+			paramName, ok := request.GetArguments()["name"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter name not found")
 			}
 
-			paramNamespace, ok := request.Params.Arguments["namespace"].(string)
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter namespace not found")
 			}
 
-			paramContent, ok := request.Params.Arguments["content"].(string)
+			paramContent, ok := request.GetArguments()["content"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter content not found")
 			}
@@ -50,27 +47,34 @@ func CreateDeployment(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool,
 				return nil, err
 			}
 			deployment.Name = paramName
+			dryRun := parseDryRun(request)
 
-			createResp, err := karmadaClient.AppsV1().Deployments(paramNamespace).Create(ctx, &deployment, metav1.CreateOptions{})
+			createResp, err := karmadaClient.AppsV1().Deployments(paramNamespace).Create(ctx, &deployment, metav1.CreateOptions{DryRun: dryRun})
 			if err != nil {
 				klog.Errorf("create deployment error: %v", err)
 				return nil, err
 			}
-
-			respBuff, err := json.Marshal(createResp)
-			if err != nil {
-				klog.Errorf("marshal created deployment error: %v", err)
-				return nil, err
+			if dryRun != nil {
+				return dryRunResult(nil, createResp)
 			}
-			return mcp.NewToolResultText(string(respBuff)), nil
+
+			return structuredResult(writeResult[*appsv1.Deployment]{Object: createResp})
 		}
 }
 
+// deploymentListResult is the result of list_deployment.
+type deploymentListResult struct {
+	listPage
+	Deployments []string `json:"deployments"`
+}
+
 func ListDeployment(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"list_deployment",
-			mcp.WithDescription("List deployments under the specific namespace in the Karmada control-plane"),
+			mcp.WithDescription("List deployments under the specific namespace in the Karmada control-plane. The result is paginated, pass nextCursor as cursor to get the next page"),
 			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withListQuery(),
+			withOutputSchema[deploymentListResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKubernetesClient(ctx)
@@ -78,27 +82,30 @@ func ListDeployment(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool, h
 				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
 			}
 
-			paramNamespace, ok := request.Params.Arguments["namespace"].(string)
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter namespace not found")
 			}
-			namespace := common.NewNamespaceQuery([]string{paramNamespace})
-			resp, err := deployment.GetDeploymentList(karmadaClient, namespace, dataselect.NoDataSelect)
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			// the deployments are listed directly, the dashboard would also fetch the pods and events of every deployment
+			resp, err := karmadaClient.AppsV1().Deployments(paramNamespace).List(ctx, query.listOptions())
 			if err != nil {
 				klog.Errorf("failed to list deployments, err: %v", err)
 				return nil, err
 			}
-			deployList := make([]string, 0)
-			for _, deployment := range resp.Deployments {
-				deployList = append(deployList, deployment.ObjectMeta.Name)
+			items := make([]*appsv1.Deployment, 0, len(resp.Items))
+			for i := range resp.Items {
+				items = append(items, &resp.Items[i])
 			}
-			r, err := json.Marshal(map[string]interface{}{
-				"deployments": deployList,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal deployments: %w", err)
+			selected, page := selectObjects(items, query)
+			deployList := make([]string, 0, len(selected))
+			for _, deployment := range selected {
+				deployList = append(deployList, deployment.Name)
 			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(deploymentListResult{listPage: page, Deployments: deployList})
 		}
 }
@@ -0,0 +1,186 @@
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	toolsets "github.com/warjiang/karmada-mcp-server/pkg/toolset"
+	"sort"
+	"sync"
+)
+
+// InitDynamicToolset returns the toolset of the meta-tools discovering and enabling the other toolsets of the group
+// at runtime, so that the tool list of the client stays small until a capability is needed.
+func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup) *toolsets.Toolset {
+	dynamicToolset := toolsets.NewToolset("dynamic", "Discover the Karmada toolsets and enable them on demand").
+		AddReadTools(
+			toolsets.NewServerTool(ListAvailableToolsets(tsg)),
+			toolsets.NewServerTool(GetToolsetTools(tsg)),
+		).
+		// enabling a toolset changes the tools of the session, so hosts should not approve it like a read,
+		// but it only adds tools and enabling it again has no effect
+		AddWriteTools(
+			toolsets.NewServerTool(EnableToolset(s, tsg)),
+		).
+		OverrideAnnotations("enable_toolset", additive, idempotent).
+		AddToolOptions(withOutput())
+	dynamicToolset.Enabled = true
+	return dynamicToolset
+}
+
+type toolsetSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type toolSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// toolsetListResult is the result of list_available_toolsets.
+type toolsetListResult struct {
+	Toolsets []toolsetSummary `json:"toolsets"`
+}
+
+// toolsetTools is the result of get_toolset_tools.
+type toolsetTools struct {
+	Toolset string        `json:"toolset"`
+	Enabled bool          `json:"enabled"`
+	Tools   []toolSummary `json:"tools"`
+}
+
+func ListAvailableToolsets(tsg *toolsets.ToolsetGroup) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_available_toolsets",
+			mcp.WithDescription("List the toolsets of the Karmada MCP server and whether they are enabled, call this first to find the toolset providing a capability, then enable it with enable_toolset"),
+			withOutputSchema[toolsetListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			summaries := make([]toolsetSummary, 0, len(tsg.Toolsets))
+			for _, name := range toolsetNames(tsg) {
+				toolset := tsg.Toolsets[name]
+				summaries = append(summaries, toolsetSummary{
+					Name:        toolset.Name,
+					Description: toolset.Description,
+					Enabled:     toolsetEnabled(ctx, tsg, name),
+				})
+			}
+			return structuredResult(toolsetListResult{Toolsets: summaries})
+		}
+}
+
+func GetToolsetTools(tsg *toolsets.ToolsetGroup) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_toolset_tools",
+			mcp.WithDescription("List the tools a toolset of the Karmada MCP server provides, use it to check a toolset is the one needed before enabling it"),
+			mcp.WithString("toolset", mcp.Required(), mcp.Description("name of the toolset"), mcp.Enum(toolsetNames(tsg)...)),
+			withOutputSchema[toolsetTools](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			paramToolset, ok := request.GetArguments()["toolset"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter toolset not found")
+			}
+
+			toolset, exists := tsg.Toolsets[paramToolset]
+			if !exists {
+				return mcp.NewToolResultError(fmt.Sprintf("toolset %s does not exist", paramToolset)), nil
+			}
+			tools := make([]toolSummary, 0)
+			for _, tool := range toolset.GetAvailableTools() {
+				tools = append(tools, toolSummary{Name: tool.Tool.Name, Description: tool.Tool.Description})
+			}
+			return structuredResult(toolsetTools{
+				Toolset: toolset.Name,
+				Enabled: toolsetEnabled(ctx, tsg, paramToolset),
+				Tools:   tools,
+			})
+		}
+}
+
+func EnableToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	// sessionMu serializes enabling toolsets for the sessions, mcp-go replaces the tools of a session as a whole
+	var sessionMu sync.Mutex
+	return mcp.NewTool(
+			"enable_toolset",
+			mcp.WithDescription("Enable a toolset of the Karmada MCP server for this session, its tools are added to the tool list of the session. Use list_available_toolsets and get_toolset_tools first to see what this will enable"),
+			mcp.WithString("toolset", mcp.Required(), mcp.Description("name of the toolset to enable"), mcp.Enum(toolsetNames(tsg)...)),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			paramToolset, ok := request.GetArguments()["toolset"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter toolset not found")
+			}
+
+			toolset, exists := tsg.Toolsets[paramToolset]
+			if !exists {
+				return mcp.NewToolResultError(fmt.Sprintf("toolset %s does not exist", paramToolset)), nil
+			}
+
+			// stdio serves a single user, the toolsets are enabled for the whole server
+			session := server.ClientSessionFromContext(ctx)
+			if session == nil || session.SessionID() == stdioSessionID {
+				enabled, err := tsg.EnableToolsetOnce(paramToolset)
+				if err != nil {
+					return nil, err
+				}
+				if !enabled {
+					return mcp.NewToolResultText(fmt.Sprintf("toolset %s is already enabled", paramToolset)), nil
+				}
+				// adding the tools notifies the clients with notifications/tools/list_changed
+				s.AddTools(toolset.GetAvailableTools()...)
+				if templates := toolset.GetResourceTemplates(); len(templates) > 0 {
+					s.AddResourceTemplates(templates...)
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("toolset %s enabled", paramToolset)), nil
+			}
+
+			// the sse and http transports are shared by several users, so the toolset is only enabled for the
+			// session calling the tool, it is disabled again when the session ends
+			sessionMu.Lock()
+			defer sessionMu.Unlock()
+			if toolsetEnabled(ctx, tsg, paramToolset) {
+				return mcp.NewToolResultText(fmt.Sprintf("toolset %s is already enabled", paramToolset)), nil
+			}
+			if err := s.AddSessionTools(session.SessionID(), toolset.GetAvailableTools()...); err != nil {
+				return nil, fmt.Errorf("failed to enable toolset %s for session: %w", paramToolset, err)
+			}
+			if templates := toolset.GetResourceTemplates(); len(templates) > 0 {
+				if err := s.AddSessionResourceTemplates(session.SessionID(), templates...); err != nil {
+					return nil, fmt.Errorf("failed to enable the resources of toolset %s for session: %w", paramToolset, err)
+				}
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("toolset %s enabled for this session", paramToolset)), nil
+		}
+}
+
+// toolsetEnabled reports whether the toolset is enabled for the whole server or for the session of the call.
+func toolsetEnabled(ctx context.Context, tsg *toolsets.ToolsetGroup, name string) bool {
+	if tsg.IsEnabled(name) {
+		return true
+	}
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithTools)
+	if !ok {
+		return false
+	}
+	sessionTools := session.GetSessionTools()
+	tools := tsg.Toolsets[name].GetAvailableTools()
+	for _, tool := range tools {
+		if _, ok := sessionTools[tool.Tool.Name]; !ok {
+			return false
+		}
+	}
+	return len(tools) > 0
+}
+
+func toolsetNames(tsg *toolsets.ToolsetGroup) []string {
+	names := make([]string, 0, len(tsg.Toolsets))
+	for name := range tsg.Toolsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,431 @@
+package karmada
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"sort"
+	"strings"
+)
+
+const (
+	// appliedOverridesAnnotation records the OverridePolicies applied to the manifest of a Work.
+	appliedOverridesAnnotation = "policy.karmada.io/applied-overrides"
+	// appliedClusterOverridesAnnotation records the ClusterOverridePolicies applied to the manifest of a Work.
+	appliedClusterOverridesAnnotation = "policy.karmada.io/applied-cluster-overrides"
+)
+
+// implicitPriority is how specifically a resource selector matches a resource, mirroring the Karmada resource detector.
+type implicitPriority int
+
+const (
+	priorityMisMatch implicitPriority = iota
+	priorityMatchAll
+	priorityMatchLabelSelector
+	priorityMatchName
+)
+
+func (p implicitPriority) String() string {
+	switch p {
+	case priorityMatchAll:
+		return "apiVersion/kind"
+	case priorityMatchLabelSelector:
+		return "labelSelector"
+	case priorityMatchName:
+		return "name"
+	default:
+		return "none"
+	}
+}
+
+// propagationReport is the result of explain_propagation.
+type propagationReport struct {
+	Resource        resourceTemplate     `json:"resource"`
+	Policy          *objectReference     `json:"policy,omitempty"`
+	MatchedPolicies []matchedPolicy      `json:"matchedPolicies"`
+	Binding         *bindingSummary      `json:"binding,omitempty"`
+	Clusters        []clusterPropagation `json:"clusters"`
+	Summary         string               `json:"summary"`
+}
+
+type resourceTemplate struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Namespace  string            `json:"namespace,omitempty"`
+	Name       string            `json:"name"`
+	Found      bool              `json:"found"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// matchedPolicy is a PropagationPolicy or ClusterPropagationPolicy whose resource selectors match the resource.
+type matchedPolicy struct {
+	objectReference
+	Priority   int32                             `json:"priority"`
+	Preemption policyv1alpha1.PreemptionBehavior `json:"preemption,omitempty"`
+	MatchedBy  string                            `json:"matchedBy"`
+	// Preferred marks the policy the resource detector would choose for an unclaimed resource.
+	Preferred bool `json:"preferred,omitempty"`
+	Claimed   bool `json:"claimed,omitempty"`
+
+	implicitPriority implicitPriority
+}
+
+// clusterPropagation is the state of the resource in one of the clusters it is scheduled to.
+type clusterPropagation struct {
+	Cluster                 string                      `json:"cluster"`
+	Replicas                int32                       `json:"replicas,omitempty"`
+	Applied                 bool                        `json:"applied"`
+	AppliedMessage          string                      `json:"appliedMessage,omitempty"`
+	Health                  workv1alpha2.ResourceHealth `json:"health,omitempty"`
+	OverridePolicies        []string                    `json:"overridePolicies,omitempty"`
+	ClusterOverridePolicies []string                    `json:"clusterOverridePolicies,omitempty"`
+	Work                    *workSummary                `json:"work,omitempty"`
+}
+
+func ExplainPropagation(getKarmadaClient GetKarmadaClientFn, mapper meta.RESTMapper, getDynamicClient GetDynamicClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"explain_propagation",
+			mcp.WithDescription(`Trace a resource template in the Karmada control-plane end to end and explain where it is in the propagation and why.
+The report contains the propagationpolicy or clusterpropagationpolicy claiming the resource and all policies matching it with their priority and preemption, the resourcebinding and its scheduling result,
+and for every scheduled member cluster the work, the overridepolicies applied to it and the status collected from the member cluster, followed by a human-readable summary`),
+			mcp.WithString("apiVersion", mcp.Required(), mcp.Description("apiVersion of the resource, e.g. apps/v1")),
+			mcp.WithString("kind", mcp.Required(), mcp.Description("kind of the resource, e.g. Deployment")),
+			mcp.WithString("namespace", mcp.Description("namespace of the resource, only required for namespace-scoped resources")),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the resource")),
+			withOutputSchema[propagationReport](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+			dynamicClient, err := getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+			}
+
+			paramAPIVersion, ok := request.GetArguments()["apiVersion"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter apiVersion not found")
+			}
+			paramKind, ok := request.GetArguments()["kind"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter kind not found")
+			}
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+			paramNamespace, _ := request.GetArguments()["namespace"].(string)
+
+			resource, namespaced, err := resourceClient(mapper, dynamicClient, paramAPIVersion, paramKind)
+			if err != nil {
+				return nil, err
+			}
+			if !namespaced {
+				paramNamespace = ""
+			} else if paramNamespace == "" {
+				return nil, fmt.Errorf("parameter namespace is required for namespace-scoped kind %s", paramKind)
+			}
+
+			report := &propagationReport{
+				Resource: resourceTemplate{
+					APIVersion: paramAPIVersion,
+					Kind:       paramKind,
+					Namespace:  paramNamespace,
+					Name:       paramName,
+				},
+				MatchedPolicies: []matchedPolicy{},
+				Clusters:        []clusterPropagation{},
+			}
+
+			obj, err := resource.Namespace(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				klog.Errorf("failed to get resource template, err: %v", err)
+				return nil, err
+			}
+			if err == nil {
+				report.Resource.Found = true
+				report.Resource.Labels = obj.GetLabels()
+				report.Policy = bindingPolicy(obj.GetAnnotations())
+				if report.MatchedPolicies, err = matchPropagationPolicies(ctx, karmadaClient, obj, report.Policy); err != nil {
+					return nil, err
+				}
+
+				if err = explainBinding(ctx, karmadaClient, report); err != nil {
+					return nil, err
+				}
+			}
+			report.Summary = summarizePropagation(report)
+
+			return structuredResult(report)
+		}
+}
+
+// matchPropagationPolicies returns the policies selecting obj, best match first, marking the claimed and the preferred one.
+func matchPropagationPolicies(ctx context.Context, karmadaClient karmadaclientset.Interface, obj *unstructured.Unstructured, claimed *objectReference) ([]matchedPolicy, error) {
+	var matched []matchedPolicy
+	if obj.GetNamespace() != "" {
+		policies, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(obj.GetNamespace()).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.Errorf("failed to list propagationpolicies, err: %v", err)
+			return nil, err
+		}
+		for _, policy := range policies.Items {
+			if p := resourceSelectorsPriority(obj, policy.Spec.ResourceSelectors); p > priorityMisMatch {
+				matched = append(matched, newMatchedPolicy("PropagationPolicy", policy.ObjectMeta, policy.Spec, p))
+			}
+		}
+	}
+	clusterPolicies, err := karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("failed to list clusterpropagationpolicies, err: %v", err)
+		return nil, err
+	}
+	for _, policy := range clusterPolicies.Items {
+		if p := resourceSelectorsPriority(obj, policy.Spec.ResourceSelectors); p > priorityMisMatch {
+			matched = append(matched, newMatchedPolicy("ClusterPropagationPolicy", policy.ObjectMeta, policy.Spec, p))
+		}
+	}
+
+	// PropagationPolicies take precedence over ClusterPropagationPolicies, then the explicit
+	// priority, the implicit priority and the name decide, as in the resource detector.
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if a.Kind != b.Kind {
+			return a.Kind == "PropagationPolicy"
+		}
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if a.implicitPriority != b.implicitPriority {
+			return a.implicitPriority > b.implicitPriority
+		}
+		return a.Name < b.Name
+	})
+	for i := range matched {
+		matched[i].Preferred = i == 0
+		matched[i].Claimed = claimed != nil && matched[i].objectReference == *claimed
+	}
+	if matched == nil {
+		matched = []matchedPolicy{}
+	}
+	return matched, nil
+}
+
+func newMatchedPolicy(kind string, objectMeta metav1.ObjectMeta, spec policyv1alpha1.PropagationSpec, p implicitPriority) matchedPolicy {
+	return matchedPolicy{
+		objectReference:  objectReference{Kind: kind, Namespace: objectMeta.Namespace, Name: objectMeta.Name},
+		Priority:         spec.ExplicitPriority(),
+		Preemption:       spec.Preemption,
+		MatchedBy:        p.String(),
+		implicitPriority: p,
+	}
+}
+
+// resourceSelectorsPriority returns the highest priority with which one of the selectors matches obj.
+func resourceSelectorsPriority(obj *unstructured.Unstructured, selectors []policyv1alpha1.ResourceSelector) implicitPriority {
+	result := priorityMisMatch
+	for _, rs := range selectors {
+		if p := resourceSelectorPriority(obj, rs); p > result {
+			result = p
+		}
+	}
+	return result
+}
+
+func resourceSelectorPriority(obj *unstructured.Unstructured, rs policyv1alpha1.ResourceSelector) implicitPriority {
+	if obj.GetAPIVersion() != rs.APIVersion || obj.GetKind() != rs.Kind ||
+		(rs.Namespace != "" && obj.GetNamespace() != rs.Namespace) {
+		return priorityMisMatch
+	}
+	// a name takes precedence over the label selector
+	if rs.Name != "" {
+		if rs.Name == obj.GetName() {
+			return priorityMatchName
+		}
+		return priorityMisMatch
+	}
+	if rs.LabelSelector == nil {
+		return priorityMatchAll
+	}
+	selector, err := metav1.LabelSelectorAsSelector(rs.LabelSelector)
+	if err != nil {
+		return priorityMisMatch
+	}
+	if selector.Matches(labels.Set(obj.GetLabels())) {
+		return priorityMatchLabelSelector
+	}
+	return priorityMisMatch
+}
+
+// explainBinding fills the binding of the resource and the state in every scheduled cluster into report.
+func explainBinding(ctx context.Context, karmadaClient karmadaclientset.Interface, report *propagationReport) error {
+	resource := report.Resource
+	bindingName := names.GenerateBindingName(resource.Kind, resource.Name)
+	var summary bindingSummary
+	if resource.Namespace != "" {
+		rb, err := karmadaClient.WorkV1alpha2().ResourceBindings(resource.Namespace).Get(ctx, bindingName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			klog.Errorf("failed to get resourcebinding, err: %v", err)
+			return err
+		}
+		summary = newBindingSummary(rb.ObjectMeta, rb.Spec, rb.Status, false)
+	} else {
+		crb, err := karmadaClient.WorkV1alpha2().ClusterResourceBindings().Get(ctx, bindingName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			klog.Errorf("failed to get clusterresourcebinding, err: %v", err)
+			return err
+		}
+		summary = newBindingSummary(crb.ObjectMeta, crb.Spec, crb.Status, false)
+	}
+	report.Binding = &summary
+
+	workName := names.GenerateWorkName(resource.Kind, resource.Name, resource.Namespace)
+	for _, target := range summary.Clusters {
+		cp := clusterPropagation{Cluster: target.Name, Replicas: target.Replicas}
+		for _, item := range summary.AggregatedStatus {
+			if item.ClusterName == target.Name {
+				cp.Applied = item.Applied
+				cp.AppliedMessage = item.AppliedMessage
+				cp.Health = item.Health
+			}
+		}
+		work, err := karmadaClient.WorkV1alpha1().Works(names.GenerateExecutionSpaceName(target.Name)).Get(ctx, workName, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			klog.Errorf("failed to get work, err: %v", err)
+			return err
+		}
+		if err == nil {
+			ws := newWorkSummary(work, true)
+			cp.Work = &ws
+			cp.OverridePolicies = appliedOverridePolicies(work.Annotations[appliedOverridesAnnotation])
+			cp.ClusterOverridePolicies = appliedOverridePolicies(work.Annotations[appliedClusterOverridesAnnotation])
+		}
+		report.Clusters = append(report.Clusters, cp)
+	}
+	return nil
+}
+
+// appliedOverridePolicies returns the names of the policies recorded in an applied overrides annotation.
+func appliedOverridePolicies(annotation string) []string {
+	if annotation == "" {
+		return nil
+	}
+	var items []struct {
+		PolicyName string `json:"policyName"`
+	}
+	if err := json.Unmarshal([]byte(annotation), &items); err != nil {
+		klog.Warningf("failed to decode applied overrides %q, err: %v", annotation, err)
+		return nil
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.PolicyName)
+	}
+	return result
+}
+
+// summarizePropagation explains in plain text how far the resource has been propagated and what blocks it.
+func summarizePropagation(report *propagationReport) string {
+	resource := report.Resource
+	ref := resource.Kind + " " + resource.Name
+	if resource.Namespace != "" {
+		ref = resource.Kind + " " + resource.Namespace + "/" + resource.Name
+	}
+	if !resource.Found {
+		return fmt.Sprintf("%s does not exist in the Karmada control-plane, there is nothing to propagate.", ref)
+	}
+
+	var lines []string
+	var preferred *matchedPolicy
+	if len(report.MatchedPolicies) > 0 {
+		preferred = &report.MatchedPolicies[0]
+	}
+	switch {
+	case report.Policy == nil && preferred == nil:
+		return fmt.Sprintf("%s is not selected by any propagationpolicy or clusterpropagationpolicy, so it stays in the Karmada control-plane. Create a policy whose resourceSelectors match it.", ref)
+	case report.Policy == nil:
+		lines = append(lines, fmt.Sprintf("%s matches %s but has not been claimed yet, check the resource detector of karmada-controller-manager.", ref, policyName(preferred.objectReference)))
+	default:
+		lines = append(lines, fmt.Sprintf("%s is claimed by %s.", ref, policyName(*report.Policy)))
+		if preferred != nil && !preferred.Claimed {
+			if preferred.Preemption == policyv1alpha1.PreemptAlways {
+				lines = append(lines, fmt.Sprintf("%s (priority %d) ranks higher and preempts, it is expected to take the resource over.", policyName(preferred.objectReference), preferred.Priority))
+			} else {
+				lines = append(lines, fmt.Sprintf("%s (priority %d) ranks higher but does not preempt, so the resource stays with the current policy.", policyName(preferred.objectReference), preferred.Priority))
+			}
+		}
+	}
+
+	binding := report.Binding
+	if binding == nil {
+		lines = append(lines, "No binding has been created for it yet.")
+		return strings.Join(lines, " ")
+	}
+	if binding.Scheduled != metav1.ConditionTrue {
+		reason := "the scheduler has not processed it yet"
+		for _, cond := range binding.Conditions {
+			if cond.Type == workv1alpha2.Scheduled && cond.Message != "" {
+				reason = cond.Reason + ": " + cond.Message
+			}
+		}
+		lines = append(lines, fmt.Sprintf("Binding %s is not scheduled, %s.", binding.Name, reason))
+	}
+	if len(report.Clusters) == 0 {
+		lines = append(lines, "It is not scheduled to any member cluster.")
+		return strings.Join(lines, " ")
+	}
+
+	var healthy []string
+	for _, cp := range report.Clusters {
+		switch {
+		case cp.Work == nil:
+			lines = append(lines, fmt.Sprintf("The work for cluster %s has not been created yet.", cp.Cluster))
+		case cp.Work.Applied != metav1.ConditionTrue:
+			msg := cp.Work.AppliedMessage
+			if msg == "" {
+				msg = cp.AppliedMessage
+			}
+			lines = append(lines, fmt.Sprintf("Applying to cluster %s has not succeeded: %s.", cp.Cluster, strings.TrimSuffix(msg, ".")))
+		case cp.Health == workv1alpha2.ResourceUnhealthy:
+			lines = append(lines, fmt.Sprintf("It is applied to cluster %s but unhealthy there.", cp.Cluster))
+		default:
+			healthy = append(healthy, cp.Cluster)
+		}
+	}
+	lines = append(lines, fmt.Sprintf("%d of %d scheduled clusters have it applied without reported problems%s.", len(healthy), len(report.Clusters), clusterList(healthy)))
+	return strings.Join(lines, " ")
+}
+
+func policyName(ref objectReference) string {
+	if ref.Namespace != "" {
+		return ref.Kind + " " + ref.Namespace + "/" + ref.Name
+	}
+	return ref.Kind + " " + ref.Name
+}
+
+func clusterList(clusters []string) string {
+	if len(clusters) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(clusters, ", ") + ")"
+}
@@ -0,0 +1,253 @@
+package karmada
+
+import (
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/pmezard/go-difflib/difflib"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// parseStringSlice returns the optional string array parameter named key.
+func parseStringSlice(request mcp.CallToolRequest, key string) ([]string, error) {
+	raw, ok := request.GetArguments()[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parameter %s should be an array of string", key)
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("parameter %s should be an array of string", key)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// parseStringMap returns the optional string map parameter named key.
+func parseStringMap(request mcp.CallToolRequest, key string) (map[string]string, error) {
+	raw, ok := request.GetArguments()[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parameter %s should be an object of string values", key)
+	}
+	result := make(map[string]string, len(items))
+	for k, v := range items {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("parameter %s should be an object of string values", key)
+		}
+		result[k] = s
+	}
+	return result, nil
+}
+
+// mergeStringMap adds the entries of overrides to current, the entries only in current such as the labels
+// set by Karmada are kept.
+func mergeStringMap(current, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return current
+	}
+	if current == nil {
+		current = make(map[string]string, len(overrides))
+	}
+	for k, v := range overrides {
+		current[k] = v
+	}
+	return current
+}
+
+// unifiedDiff renders before and after as yaml and returns their unified diff, name is used as the file name in the diff header.
+// A nil before or after is rendered as an empty document, as for creations and deletions.
+func unifiedDiff(name string, before, after interface{}) (string, error) {
+	var beforeYAML, afterYAML []byte
+	var err error
+	if before != nil {
+		if beforeYAML, err = yaml.Marshal(before); err != nil {
+			return "", err
+		}
+	}
+	if after != nil {
+		if afterYAML, err = yaml.Marshal(after); err != nil {
+			return "", err
+		}
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(beforeYAML)),
+		B:        difflib.SplitLines(string(afterYAML)),
+		FromFile: "live/" + name,
+		ToFile:   "updated/" + name,
+		Context:  3,
+	})
+}
+
+// applyPatch applies the patch to the original json document, dataStruct is the typed object used by strategic merge patch.
+func applyPatch(patchType types.PatchType, original, patch []byte, dataStruct interface{}) ([]byte, error) {
+	patch, err := yaml.YAMLToJSON(patch)
+	if err != nil {
+		return nil, err
+	}
+	switch patchType {
+	case types.MergePatchType:
+		return jsonpatch.MergePatch(original, patch)
+	case types.StrategicMergePatchType:
+		return strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+	case types.JSONPatchType:
+		p, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, err
+		}
+		return p.Apply(original)
+	default:
+		return nil, fmt.Errorf("unsupported patch type %s", patchType)
+	}
+}
+
+// restMapper maps kinds to the resources served by the Karmada apiserver. The discovery is cached for the
+// lifetime of the server and refreshed when a kind is not found, e.g. after its CRD has been installed.
+type restMapper struct {
+	*restmapper.DeferredDiscoveryRESTMapper
+}
+
+func newRESTMapper(discoveryClient discovery.DiscoveryInterface) meta.RESTMapper {
+	return restMapper{restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))}
+}
+
+func (m restMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	mapping, err := m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		return m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	}
+	return mapping, err
+}
+
+func (m restMapper) ResourceFor(resource schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	gvr, err := m.DeferredDiscoveryRESTMapper.ResourceFor(resource)
+	if meta.IsNoMatchError(err) {
+		m.Reset()
+		return m.DeferredDiscoveryRESTMapper.ResourceFor(resource)
+	}
+	return gvr, err
+}
+
+// withDryRun is the dryRun parameter shared by all write tools.
+func withDryRun() mcp.ToolOption {
+	return mcp.WithBoolean("dryRun",
+		mcp.DefaultBool(false),
+		mcp.Description("if true the change is only processed by the apiserver with server-side dry-run and nothing is persisted, the object the apiserver would persist is returned together with a unified diff against the live object"),
+	)
+}
+
+// parseDryRun returns the DryRun option of the apiserver requests for the dryRun parameter.
+func parseDryRun(request mcp.CallToolRequest) []string {
+	if dryRun, _ := request.GetArguments()["dryRun"].(bool); dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// dryRunResult returns the object the apiserver would persist and its unified diff against the live object,
+// live is nil for creations and persisted is nil for deletions.
+func dryRunResult(live, persisted runtime.Object) (*mcp.CallToolResult, error) {
+	object, diff, err := dryRunDiff(live, persisted)
+	if err != nil {
+		return nil, err
+	}
+	return structuredResult(writeResult[runtime.Object]{DryRun: true, Object: object, Diff: diff})
+}
+
+// dryRunDiff returns persisted and its unified diff against live without their managedFields.
+func dryRunDiff(live, persisted runtime.Object) (runtime.Object, string, error) {
+	name := ""
+	var before, after interface{}
+	if live != nil {
+		live = live.DeepCopyObject()
+		if accessor, err := meta.Accessor(live); err == nil {
+			accessor.SetManagedFields(nil)
+			name = accessor.GetName()
+		}
+		before = live
+	}
+	if persisted != nil {
+		persisted = persisted.DeepCopyObject()
+		if accessor, err := meta.Accessor(persisted); err == nil {
+			accessor.SetManagedFields(nil)
+			name = accessor.GetName()
+		}
+		after = persisted
+	}
+	diff, err := unifiedDiff(name, before, after)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to diff %s: %w", name, err)
+	}
+	return persisted, diff, nil
+}
+
+// policyObject is a policy replaced by the update tools, e.g. *v1alpha1.PropagationPolicy.
+type policyObject interface {
+	runtime.Object
+	metav1.Object
+}
+
+// mergePolicyUpdate returns a copy of the live policy current with the labels and annotations of desired added,
+// the caller replaces its spec. The spec is replaced on the live object, so that the permanent-id label and the
+// finalizer Karmada only adds on creation are kept. The resourceVersion argument overrides the one of desired.
+func mergePolicyUpdate[T policyObject](current, desired T, resourceVersion string) T {
+	updated := current.DeepCopyObject().(T)
+	updated.SetLabels(mergeStringMap(updated.GetLabels(), desired.GetLabels()))
+	updated.SetAnnotations(mergeStringMap(updated.GetAnnotations(), desired.GetAnnotations()))
+	if desired.GetResourceVersion() != "" {
+		updated.SetResourceVersion(desired.GetResourceVersion())
+	}
+	if resourceVersion != "" {
+		updated.SetResourceVersion(resourceVersion)
+	}
+	return updated
+}
+
+// updatePolicy writes updated with update and returns the written policy with the diff of its spec, or the dry run
+// against the live policy current. A conflict is returned as an error asking to retry with the latest version.
+func updatePolicy[T policyObject](kind string, current, updated T, dryRun []string,
+	update func(T) (T, error), spec func(T) interface{}) (*mcp.CallToolResult, error) {
+	updateResp, err := update(updated)
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			name := updated.GetName()
+			if updated.GetNamespace() != "" {
+				name = updated.GetNamespace() + "/" + name
+			}
+			return nil, fmt.Errorf("%s %s has been modified since resourceVersion %s, get the latest version and retry: %w", kind, name, updated.GetResourceVersion(), err)
+		}
+		klog.Errorf("update %s error: %v", kind, err)
+		return nil, err
+	}
+	if dryRun != nil {
+		return dryRunResult(current, updateResp)
+	}
+
+	diff, err := unifiedDiff("spec", spec(current), spec(updateResp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s: %w", kind, err)
+	}
+	return structuredResult(writeResult[T]{Object: updateResp, Diff: diff})
+}
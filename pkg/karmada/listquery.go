@@ -0,0 +1,159 @@
+package karmada
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/karmada-io/dashboard/pkg/dataselect"
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+)
+
+// defaultItemsPerPage is the page size of the list tools when itemsPerPage is not set, so that listing a
+// large control-plane does not return more items than the context of the model can hold.
+const defaultItemsPerPage = 100
+
+// listQuery is the selection of the items returned by a list tool, the cursor of the next page is its encoding.
+type listQuery struct {
+	FilterBy      string `json:"filterBy,omitempty"`
+	SortBy        string `json:"sortBy,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+	ItemsPerPage  int    `json:"itemsPerPage"`
+	Page          int    `json:"page"`
+}
+
+// listPage is embedded in the results of the list tools.
+type listPage struct {
+	TotalItems int    `json:"totalItems"`
+	Page       int    `json:"page"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// withListQuery adds the filter, sort, label selector and pagination parameters shared by the list tools.
+func withListQuery() mcp.ToolOption {
+	options := []mcp.ToolOption{
+		mcp.WithString("filterBy", mcp.Description("comma separated pairs of property and value, only the items whose property contains the value are returned, e.g. name,nginx. The properties are name and namespace")),
+		mcp.WithString("sortBy", mcp.Description("comma separated pairs of order and property, the order is a for ascending and d for descending, e.g. d,creationTimestamp,a,name. The properties are name, namespace and creationTimestamp")),
+		mcp.WithString("labelSelector", mcp.Description("only return the items matching the label selector, e.g. app=nginx,tier!=frontend")),
+		mcp.WithNumber("itemsPerPage", mcp.DefaultNumber(defaultItemsPerPage), mcp.Min(0), mcp.Description("number of items per page, 0 returns all the items")),
+		mcp.WithNumber("page", mcp.DefaultNumber(1), mcp.Min(1), mcp.Description("page to return, starting at 1")),
+		mcp.WithString("cursor", mcp.Description("nextCursor of a previous result, returns the next page of the same query, filterBy, sortBy, labelSelector, itemsPerPage and page are then ignored and the other parameters must be passed again")),
+	}
+	return func(tool *mcp.Tool) {
+		for _, option := range options {
+			option(tool)
+		}
+	}
+}
+
+// parseListQuery returns the query of the list parameters, or the one encoded in the cursor parameter.
+func parseListQuery(request mcp.CallToolRequest) (listQuery, error) {
+	if cursor, _ := request.GetArguments()["cursor"].(string); cursor != "" {
+		return decodeCursor(cursor)
+	}
+	query := listQuery{
+		ItemsPerPage: mcp.ParseInt(request, "itemsPerPage", defaultItemsPerPage),
+		Page:         mcp.ParseInt(request, "page", 1),
+	}
+	query.FilterBy, _ = request.GetArguments()["filterBy"].(string)
+	query.SortBy, _ = request.GetArguments()["sortBy"].(string)
+	query.LabelSelector, _ = request.GetArguments()["labelSelector"].(string)
+	if query.ItemsPerPage < 0 {
+		return query, fmt.Errorf("parameter itemsPerPage must not be negative")
+	}
+	if query.Page < 1 {
+		return query, fmt.Errorf("parameter page must be at least 1")
+	}
+	// the dashboard ignores malformed lists instead of rejecting them
+	filterBy := splitPairs(query.FilterBy)
+	if len(filterBy)%2 != 0 {
+		return query, fmt.Errorf("parameter filterBy must be pairs of property and value")
+	}
+	for i := 0; i < len(filterBy); i += 2 {
+		// the filter values are strings, other properties cannot be compared with them
+		if property := dataselect.PropertyName(filterBy[i]); property != dataselect.NameProperty && property != dataselect.NamespaceProperty {
+			return query, fmt.Errorf("parameter filterBy does not support property %s", filterBy[i])
+		}
+	}
+	if len(splitPairs(query.SortBy))%2 != 0 {
+		return query, fmt.Errorf("parameter sortBy must be pairs of order and property")
+	}
+	return query, nil
+}
+
+func decodeCursor(cursor string) (listQuery, error) {
+	query := listQuery{}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return query, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if err = json.Unmarshal(raw, &query); err != nil {
+		return query, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return query, nil
+}
+
+func (q listQuery) cursor() string {
+	raw, _ := json.Marshal(q)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func (q listQuery) listOptions() metav1.ListOptions {
+	return metav1.ListOptions{LabelSelector: q.LabelSelector}
+}
+
+func (q listQuery) dataSelectQuery() *dataselect.DataSelectQuery {
+	pagination := dataselect.NoPagination
+	if q.ItemsPerPage > 0 {
+		// pages of the dashboard start at 0
+		pagination = dataselect.NewPaginationQuery(q.ItemsPerPage, q.Page-1)
+	}
+	return dataselect.NewDataSelectQuery(pagination, dataselect.NewSortQuery(splitPairs(q.SortBy)), dataselect.NewFilterQuery(splitPairs(q.FilterBy)))
+}
+
+func splitPairs(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
+
+// objectCell exposes the properties of an object to the dashboard data select.
+type objectCell[T metav1.Object] struct {
+	object T
+}
+
+func (c objectCell[T]) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(c.object.GetName())
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(c.object.GetNamespace())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(c.object.GetCreationTimestamp().Time)
+	default:
+		return nil
+	}
+}
+
+// selectObjects filters, sorts and paginates the objects listed with the label selector of the query.
+func selectObjects[T metav1.Object](objects []T, query listQuery) ([]T, listPage) {
+	cells := make([]dataselect.DataCell, 0, len(objects))
+	for _, object := range objects {
+		cells = append(cells, objectCell[T]{object: object})
+	}
+	selected, total := dataselect.GenericDataSelectWithFilter(cells, query.dataSelectQuery())
+	result := make([]T, 0, len(selected))
+	for _, cell := range selected {
+		result = append(result, cell.(objectCell[T]).object)
+	}
+
+	page := listPage{TotalItems: total, Page: query.Page}
+	if query.ItemsPerPage > 0 && query.Page*query.ItemsPerPage < total {
+		next := query
+		next.Page++
+		page.NextCursor = next.cursor()
+	}
+	return result, page
+}
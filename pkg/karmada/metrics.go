@@ -0,0 +1,53 @@
+package karmada
+
+import (
+	"context"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/warjiang/karmada-mcp-server/pkg/audit"
+	"github.com/warjiang/karmada-mcp-server/pkg/metrics"
+	"github.com/warjiang/karmada-mcp-server/pkg/toolpolicy"
+)
+
+// unknownTool is the tool label of the calls of tools the server does not have, the names sent by the
+// clients are not used as label values since any client could create unbounded series.
+const unknownTool = "unknown"
+
+// addMetricsHooks counts the tool calls and sessions in the Prometheus metrics, the status of a call is
+// the same as in the audit log.
+func addMetricsHooks(hooks *server.Hooks) {
+	// the tools enabled for the session only are known too
+	toolLabel := func(ctx context.Context, name string) string {
+		if _, ok := toolpolicy.CalledTool(ctx, name); !ok {
+			return unknownTool
+		}
+		return name
+	}
+	calls := &callTracker{}
+	hooks.AddBeforeCallTool(calls.start)
+	hooks.AddAfterCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest, result any) {
+		status := audit.StatusSuccess
+		if result, ok := result.(*mcp.CallToolResult); ok && result.IsError {
+			status = audit.StatusError
+		}
+		observeToolCall(toolLabel(ctx, message.Params.Name), status, calls.stop(ctx, id).Seconds())
+	})
+	hooks.AddOnError(func(ctx context.Context, id any, method mcp.MCPMethod, message any, err error) {
+		request, ok := message.(*mcp.CallToolRequest)
+		if method != mcp.MethodToolsCall || !ok {
+			return
+		}
+		observeToolCall(toolLabel(ctx, request.Params.Name), audit.StatusFailure, calls.stop(ctx, id).Seconds())
+	})
+	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+		metrics.ActiveSessions.Inc()
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		metrics.ActiveSessions.Dec()
+	})
+}
+
+func observeToolCall(tool string, status audit.Status, seconds float64) {
+	metrics.ToolCalls.WithLabelValues(tool, string(status)).Inc()
+	metrics.ToolCallDuration.WithLabelValues(tool).Observe(seconds)
+}
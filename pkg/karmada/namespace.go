@@ -2,12 +2,13 @@ package karmada
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"github.com/karmada-io/dashboard/pkg/dataselect"
 	ns "github.com/karmada-io/dashboard/pkg/resource/namespace"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func CreateNamespace(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -16,6 +17,8 @@ func CreateNamespace(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool,
 			mcp.WithDescription("Create a namespace resources in the Karmada control-plane"),
 			mcp.WithString("name", mcp.Required(), mcp.Description("name for the namespace")),
 			mcp.WithBoolean("skipAutoPropagation", mcp.Required(), mcp.Description("whether propagation the namespace automatically")),
+			withDryRun(),
+			withOutputSchema[writeResult[*corev1.Namespace]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKubernetesClient(ctx)
@@ -23,15 +26,26 @@ func CreateNamespace(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool,
 				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
 			}
 
-			paramName, ok := request.Params.Arguments["name"].(string)
+			paramName, ok := request.GetArguments()["name"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter name not found")
 			}
 
-			paramSkipAutoPropagation, ok := request.Params.Arguments["skipAutoPropagation"].(bool)
+			paramSkipAutoPropagation, ok := request.GetArguments()["skipAutoPropagation"].(bool)
 			if !ok {
 				return nil, fmt.Errorf("parameter name not found")
 			}
+			if dryRun := parseDryRun(request); dryRun != nil {
+				namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: paramName}}
+				if paramSkipAutoPropagation {
+					namespace.Labels = map[string]string{policyv1alpha1.NamespaceSkipAutoPropagationLabel: "true"}
+				}
+				createResp, err := karmadaClient.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{DryRun: dryRun})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create namespace: %w", err)
+				}
+				return dryRunResult(nil, createResp)
+			}
 			spec := &ns.NamespaceSpec{
 				Name:                paramName,
 				SkipAutoPropagation: paramSkipAutoPropagation,
@@ -40,14 +54,22 @@ func CreateNamespace(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool,
 				return nil, fmt.Errorf("failed to create namespace: %w", err)
 			}
 
-			return mcp.NewToolResultText("create namespace success"), nil
+			return structuredResult(writeResult[*corev1.Namespace]{Message: "create namespace success"})
 		}
 }
 
+// namespaceListResult is the result of list_namespace.
+type namespaceListResult struct {
+	listPage
+	Namespaces []string `json:"namespaces"`
+}
+
 func ListNamespace(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"list_namespace",
-			mcp.WithDescription("Return all namespace resources in the Karmada control-plane"),
+			mcp.WithDescription("Return the namespace resources in the Karmada control-plane. The result is paginated, pass nextCursor as cursor to get the next page"),
+			withListQuery(),
+			withOutputSchema[namespaceListResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKubernetesClient(ctx)
@@ -55,22 +77,25 @@ func ListNamespace(getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool, ha
 				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
 			}
 
-			resp, err := ns.GetNamespaceList(karmadaClient, dataselect.NoDataSelect)
+			query, err := parseListQuery(request)
 			if err != nil {
-				return nil, fmt.Errorf("failed to list namespace: %w", err)
-			}
-			nsList := make([]string, 0)
-			for _, namespace := range resp.Namespaces {
-				nsList = append(nsList, namespace.ObjectMeta.Name)
+				return nil, err
 			}
 
-			r, err := json.Marshal(map[string]interface{}{
-				"namespaces": nsList,
-			})
+			resp, err := karmadaClient.CoreV1().Namespaces().List(ctx, query.listOptions())
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal namespaces: %w", err)
+				return nil, fmt.Errorf("failed to list namespace: %w", err)
+			}
+			items := make([]*corev1.Namespace, 0, len(resp.Items))
+			for i := range resp.Items {
+				items = append(items, &resp.Items[i])
+			}
+			selected, page := selectObjects(items, query)
+			nsList := make([]string, 0, len(selected))
+			for _, namespace := range selected {
+				nsList = append(nsList, namespace.Name)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(namespaceListResult{listPage: page, Namespaces: nsList})
 		}
 }
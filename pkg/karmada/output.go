@@ -0,0 +1,156 @@
+package karmada
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/invopop/jsonschema"
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"reflect"
+	"strings"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// apiPackagePrefixes are the packages of the Kubernetes and Karmada API types.
+var apiPackagePrefixes = []string{"k8s.io/api/", "k8s.io/apimachinery/", "github.com/karmada-io/"}
+
+// withOutputSchema publishes the JSON schema of T, the structured result of the tool, as its output schema.
+// Only the fields of Kubernetes and Karmada API objects are described, not the schemas of their values, since
+// their full schemas would bloat the tool list, and types with a custom JSON encoding, such as metav1.Time,
+// accept any value.
+func withOutputSchema[T any]() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		reflector := jsonschema.Reflector{
+			DoNotReference:            true,
+			Anonymous:                 true,
+			AllowAdditionalProperties: true,
+			Mapper:                    outputSchemaMapper,
+		}
+		var zero T
+		schema := reflector.Reflect(zero)
+		schema.Version = ""
+		// structured content is always an object, also for the types mapped to any value
+		if schema.Type == "" {
+			schema.Type = "object"
+		}
+		raw, err := json.Marshal(schema)
+		if err != nil {
+			klog.Errorf("failed to generate the output schema of tool %s, err: %v", tool.Name, err)
+			return
+		}
+		tool.RawOutputSchema = raw
+	}
+}
+
+func outputSchemaMapper(t reflect.Type) *jsonschema.Schema {
+	if isJSONMarshaler(t) {
+		return &jsonschema.Schema{}
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, prefix := range apiPackagePrefixes {
+		if strings.HasPrefix(t.PkgPath(), prefix) {
+			schema := &jsonschema.Schema{Type: "object", Properties: jsonschema.NewProperties()}
+			addAPIFields(schema, t)
+			return schema
+		}
+	}
+	return nil
+}
+
+// addAPIFields adds the JSON fields of the API type t to the properties of schema, inlining the embedded
+// structs such as metav1.TypeMeta. The fields are only described by the JSON type of their values.
+func addAPIFields(schema *jsonschema.Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && !isJSONMarshaler(embedded) {
+				addAPIFields(schema, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties.Set(name, &jsonschema.Schema{Type: jsonType(field.Type)})
+	}
+}
+
+// jsonType returns the JSON type values of t are encoded to, empty for any value.
+func jsonType(t reflect.Type) string {
+	if isJSONMarshaler(t) {
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonType(t.Elem())
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		// byte slices are encoded as base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+func isJSONMarshaler(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType)
+}
+
+// kubernetesObject describes the fields common to Kubernetes objects, for the tools returning objects of any kind.
+type kubernetesObject struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Metadata   metav1.ObjectMeta      `json:"metadata"`
+	Spec       map[string]interface{} `json:"spec,omitempty"`
+	Status     map[string]interface{} `json:"status,omitempty"`
+}
+
+// writeResult is the structured result of the write tools, T is the type of the object they write. Object is
+// the object written, or for dry runs the object the apiserver would persist, and Diff the unified diff of the
+// change when the tool reports it. Calls without an object, such as deletions, describe their outcome in Message.
+// All the fields are optional, so that the confirmation plans returned instead of executing destructive tools
+// match the schema too.
+type writeResult[T any] struct {
+	Message string `json:"message,omitempty"`
+	DryRun  bool   `json:"dryRun,omitempty"`
+	Object  T      `json:"object,omitempty"`
+	Diff    string `json:"diff,omitempty"`
+}
+
+// structuredResult returns result as the structured content of the tool result, together with its
+// JSON encoding as text content for the clients not supporting structured content.
+func structuredResult(result interface{}) (*mcp.CallToolResult, error) {
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return mcp.NewToolResultStructured(result, string(r)), nil
+}
@@ -0,0 +1,435 @@
+package karmada
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const overridePolicyContentExample = `policy content which in form of yaml, one overridepolicy yaml file likes:
+apiVersion: policy.karmada.io/v1alpha1
+kind: OverridePolicy
+metadata:
+  name: nginx-override
+spec:
+  resourceSelectors:
+    - apiVersion: apps/v1
+      kind: Deployment
+      name: nginx
+  overrideRules:
+    - targetCluster:
+        clusterNames:
+          - member2
+      overriders:
+        imageOverrider:
+          - component: Registry
+            operator: replace
+            value: registry.example.io
+`
+
+// overriderToolOptions are the structured arguments shared by the override policy write tools,
+// they are translated into one override rule by buildOverrideSpec.
+func overriderToolOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("content", mcp.Description(overridePolicyContentExample+"the other overrider arguments are appended to it as an extra override rule")),
+		mcp.WithString("resourceApiVersion", mcp.Description("apiVersion of the resource to override, e.g. apps/v1")),
+		mcp.WithString("resourceKind", mcp.Description("kind of the resource to override, e.g. Deployment")),
+		mcp.WithString("resourceName", mcp.Description("name of the resource to override, all resources of the kind are selected if not specified")),
+		mcp.WithArray("clusters", mcp.Items(map[string]interface{}{"type": "string"}), mcp.Description("names of the clusters the override rule applies to, all clusters if not specified")),
+		mcp.WithString("imageRegistry", mcp.Description("replace the registry of all container images, e.g. registry.example.io")),
+		mcp.WithString("imageRepository", mcp.Description("replace the repository of all container images, e.g. library/nginx")),
+		mcp.WithString("imageTag", mcp.Description("replace the tag of all container images, e.g. 1.25")),
+		mcp.WithString("containerName", mcp.Description("container whose command or args are overridden, required by command and args")),
+		mcp.WithArray("command", mcp.Items(map[string]interface{}{"type": "string"}), mcp.Description("command entries to add to the container")),
+		mcp.WithArray("args", mcp.Items(map[string]interface{}{"type": "string"}), mcp.Description("args to add to the container")),
+		mcp.WithObject("labels", mcp.AdditionalProperties(map[string]interface{}{"type": "string"}), mcp.Description("labels to add or replace")),
+		mcp.WithObject("annotations", mcp.AdditionalProperties(map[string]interface{}{"type": "string"}), mcp.Description("annotations to add or replace")),
+		mcp.WithArray("plaintext", mcp.Items(map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"path":     map[string]interface{}{"type": "string", "description": "JSON pointer of the field, e.g. /spec/replicas"},
+				"operator": map[string]interface{}{"type": "string", "enum": []string{"add", "remove", "replace"}},
+				"value":    map[string]interface{}{"description": "value of the field, not needed by remove"},
+			},
+			"required": []string{"path", "operator"},
+		}), mcp.Description("plaintext JSON patch operations")),
+	}
+}
+
+// buildOverrideSpec assembles an OverrideSpec from the raw yaml content and the structured overrider arguments,
+// which are appended to the spec of the content, or to base when there is no content. The metadata of the content
+// is returned with it.
+func buildOverrideSpec(request mcp.CallToolRequest, base v1alpha1.OverrideSpec) (v1alpha1.OverrideSpec, metav1.ObjectMeta, error) {
+	policy := struct {
+		metav1.ObjectMeta `json:"metadata,omitempty"`
+		Spec              v1alpha1.OverrideSpec `json:"spec"`
+	}{Spec: base}
+	paramContent, _ := request.GetArguments()["content"].(string)
+	if paramContent != "" {
+		policy.Spec = v1alpha1.OverrideSpec{}
+		if err := yaml.Unmarshal([]byte(paramContent), &policy); err != nil {
+			return policy.Spec, policy.ObjectMeta, fmt.Errorf("failed to unmarshal content: %w", err)
+		}
+	}
+	spec := policy.Spec
+
+	paramAPIVersion, _ := request.GetArguments()["resourceApiVersion"].(string)
+	paramKind, _ := request.GetArguments()["resourceKind"].(string)
+	paramResourceName, _ := request.GetArguments()["resourceName"].(string)
+	if paramAPIVersion != "" || paramKind != "" {
+		if paramAPIVersion == "" || paramKind == "" {
+			return spec, policy.ObjectMeta, fmt.Errorf("parameter resourceApiVersion and resourceKind should be specified together")
+		}
+		spec.ResourceSelectors = append(spec.ResourceSelectors, v1alpha1.ResourceSelector{
+			APIVersion: paramAPIVersion,
+			Kind:       paramKind,
+			Name:       paramResourceName,
+		})
+	}
+
+	overriders := v1alpha1.Overriders{}
+	for _, image := range []struct {
+		param     string
+		component v1alpha1.ImageComponent
+	}{
+		{"imageRegistry", v1alpha1.Registry},
+		{"imageRepository", v1alpha1.Repository},
+		{"imageTag", v1alpha1.Tag},
+	} {
+		if value, _ := request.GetArguments()[image.param].(string); value != "" {
+			overriders.ImageOverrider = append(overriders.ImageOverrider, v1alpha1.ImageOverrider{
+				Component: image.component,
+				Operator:  v1alpha1.OverriderOpReplace,
+				Value:     value,
+			})
+		}
+	}
+
+	paramContainerName, _ := request.GetArguments()["containerName"].(string)
+	paramCommand, err := parseStringSlice(request, "command")
+	if err != nil {
+		return spec, policy.ObjectMeta, err
+	}
+	paramArgs, err := parseStringSlice(request, "args")
+	if err != nil {
+		return spec, policy.ObjectMeta, err
+	}
+	if (len(paramCommand) > 0 || len(paramArgs) > 0) && paramContainerName == "" {
+		return spec, policy.ObjectMeta, fmt.Errorf("parameter containerName is required when overriding command or args")
+	}
+	if len(paramCommand) > 0 {
+		overriders.CommandOverrider = append(overriders.CommandOverrider, v1alpha1.CommandArgsOverrider{
+			ContainerName: paramContainerName,
+			Operator:      v1alpha1.OverriderOpAdd,
+			Value:         paramCommand,
+		})
+	}
+	if len(paramArgs) > 0 {
+		overriders.ArgsOverrider = append(overriders.ArgsOverrider, v1alpha1.CommandArgsOverrider{
+			ContainerName: paramContainerName,
+			Operator:      v1alpha1.OverriderOpAdd,
+			Value:         paramArgs,
+		})
+	}
+
+	paramLabels, err := parseStringMap(request, "labels")
+	if err != nil {
+		return spec, policy.ObjectMeta, err
+	}
+	if len(paramLabels) > 0 {
+		overriders.LabelsOverrider = append(overriders.LabelsOverrider, v1alpha1.LabelAnnotationOverrider{
+			Operator: v1alpha1.OverriderOpAdd,
+			Value:    paramLabels,
+		})
+	}
+	paramAnnotations, err := parseStringMap(request, "annotations")
+	if err != nil {
+		return spec, policy.ObjectMeta, err
+	}
+	if len(paramAnnotations) > 0 {
+		overriders.AnnotationsOverrider = append(overriders.AnnotationsOverrider, v1alpha1.LabelAnnotationOverrider{
+			Operator: v1alpha1.OverriderOpAdd,
+			Value:    paramAnnotations,
+		})
+	}
+
+	if raw, ok := request.GetArguments()["plaintext"]; ok && raw != nil {
+		buf, err := json.Marshal(raw)
+		if err != nil {
+			return spec, policy.ObjectMeta, fmt.Errorf("failed to marshal parameter plaintext: %w", err)
+		}
+		var plaintext []v1alpha1.PlaintextOverrider
+		if err = json.Unmarshal(buf, &plaintext); err != nil {
+			return spec, policy.ObjectMeta, fmt.Errorf("parameter plaintext is invalid: %w", err)
+		}
+		overriders.Plaintext = append(overriders.Plaintext, plaintext...)
+	}
+
+	paramClusters, err := parseStringSlice(request, "clusters")
+	if err != nil {
+		return spec, policy.ObjectMeta, err
+	}
+	if !emptyOverriders(overriders) {
+		rule := v1alpha1.RuleWithCluster{Overriders: overriders}
+		if len(paramClusters) > 0 {
+			rule.TargetCluster = &v1alpha1.ClusterAffinity{ClusterNames: paramClusters}
+		}
+		spec.OverrideRules = append(spec.OverrideRules, rule)
+	} else if len(paramClusters) > 0 {
+		return spec, policy.ObjectMeta, fmt.Errorf("parameter clusters is specified without any overrider")
+	}
+
+	if len(spec.ResourceSelectors) == 0 {
+		return spec, policy.ObjectMeta, fmt.Errorf("no resource selected, specify resourceApiVersion and resourceKind or resourceSelectors in content")
+	}
+	if len(spec.OverrideRules) == 0 && len(spec.Overriders.Plaintext) == 0 && spec.TargetCluster == nil {
+		return spec, policy.ObjectMeta, fmt.Errorf("no overrider specified")
+	}
+	return spec, policy.ObjectMeta, nil
+}
+
+// emptyOverriders reports whether no overrider is set.
+func emptyOverriders(o v1alpha1.Overriders) bool {
+	return len(o.Plaintext) == 0 && len(o.ImageOverrider) == 0 && len(o.CommandOverrider) == 0 &&
+		len(o.ArgsOverrider) == 0 && len(o.LabelsOverrider) == 0 && len(o.AnnotationsOverrider) == 0 &&
+		len(o.FieldOverrider) == 0
+}
+
+func CreateOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"create_overridepolicy",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Create an overridepolicy resources in the Karmada control-plane, overridepolicy customizes resources per member cluster, e.g. use another image registry in a cluster"),
+				mcp.WithString("name", mcp.Required(), mcp.Description("name for overridepolicy")),
+				mcp.WithString("namespace", mcp.Required(), mcp.Description("namespace for overridepolicy")),
+				withDryRun(),
+				withOutputSchema[writeResult[*v1alpha1.OverridePolicy]](),
+			}, overriderToolOptions()...)...,
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			spec, _, err := buildOverrideSpec(request, v1alpha1.OverrideSpec{})
+			if err != nil {
+				return nil, err
+			}
+			overridePolicy := v1alpha1.OverridePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: paramName, Namespace: paramNamespace},
+				Spec:       spec,
+			}
+
+			dryRun := parseDryRun(request)
+			createResp, err := karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).Create(ctx, &overridePolicy, metav1.CreateOptions{DryRun: dryRun})
+			if err != nil {
+				klog.Errorf("create overridepolicy error: %v", err)
+				return nil, err
+			}
+			if dryRun != nil {
+				return dryRunResult(nil, createResp)
+			}
+
+			return structuredResult(writeResult[*v1alpha1.OverridePolicy]{Object: createResp})
+		}
+}
+
+func UpdateOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"update_overridepolicy",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Update an existing overridepolicy in the Karmada control-plane without deleting it, returns the updated overridepolicy and the diff of its spec. The spec of content replaces the current one and its labels and annotations are added to the current ones, the other overrider arguments are appended as an extra override rule to the spec of content, or to the current spec without content"),
+				mcp.WithString("name", mcp.Required(), mcp.Description("name for overridepolicy")),
+				mcp.WithString("namespace", mcp.Required(), mcp.Description("namespace for overridepolicy")),
+				mcp.WithString("resourceVersion", mcp.Description("resourceVersion the update is based on, the update fails if the overridepolicy has been changed since, overrides metadata.resourceVersion of the content. If both are empty the latest version is updated")),
+				withDryRun(),
+				withOutputSchema[writeResult[*v1alpha1.OverridePolicy]](),
+			}, overriderToolOptions()...)...,
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			paramResourceVersion, _ := request.GetArguments()["resourceVersion"].(string)
+
+			current, err := karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("get overridepolicy error: %v", err)
+				return nil, err
+			}
+			spec, objectMeta, err := buildOverrideSpec(request, current.Spec)
+			if err != nil {
+				return nil, err
+			}
+			updated := mergePolicyUpdate(current, &v1alpha1.OverridePolicy{ObjectMeta: objectMeta}, paramResourceVersion)
+			updated.Spec = spec
+
+			dryRun := parseDryRun(request)
+			return updatePolicy("overridepolicy", current, updated, dryRun,
+				func(policy *v1alpha1.OverridePolicy) (*v1alpha1.OverridePolicy, error) {
+					return karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).Update(ctx, policy, metav1.UpdateOptions{DryRun: dryRun})
+				},
+				func(policy *v1alpha1.OverridePolicy) interface{} { return policy.Spec },
+			)
+		}
+}
+
+// overridePolicyListResult is the result of list_overridepolicy.
+type overridePolicyListResult struct {
+	listPage
+	OverridePolicies []string `json:"overridePolicies"`
+}
+
+func ListOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_overridepolicy",
+			mcp.WithDescription("List overridepolicies under the specific namespace in the Karmada control-plane. The result is paginated, pass nextCursor as cursor to get the next page"),
+			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withListQuery(),
+			withOutputSchema[overridePolicyListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).List(ctx, query.listOptions())
+			if err != nil {
+				klog.Errorf("failed to list overridepolicies, err: %v", err)
+				return nil, err
+			}
+			items := make([]*v1alpha1.OverridePolicy, 0, len(resp.Items))
+			for i := range resp.Items {
+				items = append(items, &resp.Items[i])
+			}
+			selected, page := selectObjects(items, query)
+			overridePolicyList := make([]string, 0, len(selected))
+			for _, overridePolicy := range selected {
+				overridePolicyList = append(overridePolicyList, overridePolicy.Name)
+			}
+			return structuredResult(overridePolicyListResult{listPage: page, OverridePolicies: overridePolicyList})
+		}
+}
+
+func GetOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_overridepolicy",
+			mcp.WithDescription("Get overridepolicy detailed yaml under the specific namespace in the Karmada control-plane"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for overridepolicy")),
+			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withOutputSchema[v1alpha1.OverridePolicy](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			resp, err := karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get overridepolicy, err: %v", err)
+				return nil, err
+			}
+			return structuredResult(resp)
+		}
+}
+
+func DeleteOverridePolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"delete_overridepolicy",
+			mcp.WithDescription("Delete overridepolicy under the specific namespace in the Karmada control-plane"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for overridepolicy")),
+			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.OverridePolicy]](),
+			withConfirmation(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			dryRun := parseDryRun(request)
+			if dryRun != nil {
+				live, err := karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+				if err != nil {
+					klog.Errorf("get overridepolicy error: %v", err)
+					return nil, err
+				}
+				if err = karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).Delete(ctx, paramName, metav1.DeleteOptions{DryRun: dryRun}); err != nil {
+					klog.ErrorS(err, "Failed to delete overridepolicy")
+					return nil, err
+				}
+				return dryRunResult(live, nil)
+			}
+
+			err = karmadaClient.PolicyV1alpha1().OverridePolicies(paramNamespace).Delete(ctx, paramName, metav1.DeleteOptions{})
+			if err != nil {
+				klog.ErrorS(err, "Failed to delete overridepolicy")
+				return nil, err
+			}
+
+			return structuredResult(writeResult[*v1alpha1.OverridePolicy]{Message: "delete overridepolicy success"})
+		}
+}
@@ -4,13 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/karmada-io/dashboard/pkg/dataselect"
-	"github.com/karmada-io/dashboard/pkg/resource/common"
 	"github.com/karmada-io/dashboard/pkg/resource/propagationpolicy"
 	"github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
 )
@@ -50,7 +50,8 @@ spec:
                 - member2
             weight: 1
 `)),
-			//mcp.WithBoolean("dry-run", mcp.DefaultBool(true), mcp.Description("dry-run options, if true the resource will not be applied to apiserver directly")),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.PropagationPolicy]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKarmadaClient(ctx)
@@ -58,17 +59,17 @@ spec:
 				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
 			}
 
-			paramName, ok := request.Params.Arguments["name"].(string)
+			paramName, ok := request.GetArguments()["name"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter name not found")
 			}
 
-			paramNamespace, ok := request.Params.Arguments["namespace"].(string)
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter namespace not found")
 			}
 
-			paramContent, ok := request.Params.Arguments["content"].(string)
+			paramContent, ok := request.GetArguments()["content"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter content not found")
 			}
@@ -78,27 +79,34 @@ spec:
 				return nil, err
 			}
 			propagationPolicy.Name = paramName
+			dryRun := parseDryRun(request)
 
-			createResp, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).Create(ctx, &propagationPolicy, metav1.CreateOptions{})
+			createResp, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).Create(ctx, &propagationPolicy, metav1.CreateOptions{DryRun: dryRun})
 			if err != nil {
 				klog.Errorf("create propagationpolicy error: %v", err)
 				return nil, err
 			}
-
-			respBuff, err := json.Marshal(createResp)
-			if err != nil {
-				klog.Errorf("marshal created propagationpolicy error: %v", err)
-				return nil, err
+			if dryRun != nil {
+				return dryRunResult(nil, createResp)
 			}
-			return mcp.NewToolResultText(string(respBuff)), nil
+
+			return structuredResult(writeResult[*v1alpha1.PropagationPolicy]{Object: createResp})
 		}
 }
 
-func ListPropagationPolicy(getKarmadaClient GetKarmadaClientFn, getKubernetesClient GetKubernetesClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+// propagationPolicyListResult is the result of list_propagationpolicy.
+type propagationPolicyListResult struct {
+	listPage
+	PropagationPolicies []string `json:"propagationPolicies"`
+}
+
+func ListPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"list_propagationpolicy",
-			mcp.WithDescription("List propagationpolicies under the specific namespace in the Karmada control-plane"),
+			mcp.WithDescription("List propagationpolicies under the specific namespace in the Karmada control-plane. The result is paginated, pass nextCursor as cursor to get the next page"),
 			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withListQuery(),
+			withOutputSchema[propagationPolicyListResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKarmadaClient(ctx)
@@ -106,36 +114,31 @@ func ListPropagationPolicy(getKarmadaClient GetKarmadaClientFn, getKubernetesCli
 				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
 			}
 
-			kubernetesClient, err := getKubernetesClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get Kubernetes client: %v", err)
-			}
-
-			paramNamespace, ok := request.Params.Arguments["namespace"].(string)
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter namespace not found")
 			}
 
-			namespace := common.NewNamespaceQuery([]string{paramNamespace})
-			dataSelect := dataselect.NoDataSelect
-			resp, err := propagationpolicy.GetPropagationPolicyList(karmadaClient, kubernetesClient, namespace, dataSelect)
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
 
+			resp, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).List(ctx, query.listOptions())
 			if err != nil {
 				klog.Errorf("failed to list propagationpolicies, err: %v", err)
 				return nil, err
 			}
-			propagationPolicyList := make([]string, 0)
-			for _, propagationPolicy := range resp.PropagationPolicys {
-				propagationPolicyList = append(propagationPolicyList, propagationPolicy.ObjectMeta.Name)
+			items := make([]*v1alpha1.PropagationPolicy, 0, len(resp.Items))
+			for i := range resp.Items {
+				items = append(items, &resp.Items[i])
 			}
-			r, err := json.Marshal(map[string]interface{}{
-				"propagationPolicies": propagationPolicyList,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal propagationpolicies: %w", err)
+			selected, page := selectObjects(items, query)
+			propagationPolicyList := make([]string, 0, len(selected))
+			for _, propagationPolicy := range selected {
+				propagationPolicyList = append(propagationPolicyList, propagationPolicy.Name)
 			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(propagationPolicyListResult{listPage: page, PropagationPolicies: propagationPolicyList})
 		}
 }
 
@@ -145,6 +148,7 @@ func GetPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, h
 			mcp.WithDescription("Get propagationpolicy detailed yaml under the specific namespace in the Karmada control-plane"),
 			mcp.WithString("name", mcp.Required(), mcp.Description("name for propagationpolicy")),
 			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withOutputSchema[propagationpolicy.PropagationPolicyDetail](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKarmadaClient(ctx)
@@ -152,12 +156,12 @@ func GetPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, h
 				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
 			}
 
-			paramName, ok := request.Params.Arguments["name"].(string)
+			paramName, ok := request.GetArguments()["name"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter name not found")
 			}
 
-			paramNamespace, ok := request.Params.Arguments["namespace"].(string)
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter namespace not found")
 			}
@@ -167,12 +171,7 @@ func GetPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, h
 				klog.Errorf("failed to get propagationpolicy, err: %v", err)
 				return nil, err
 			}
-			r, err := json.Marshal(resp)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal propagationpolicy: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(resp)
 		}
 }
 
@@ -182,6 +181,9 @@ func DeletePropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool
 			mcp.WithDescription("Delete propagationpolicy under the specific namespace in the Karmada control-plane"),
 			mcp.WithString("name", mcp.Required(), mcp.Description("name for propagationpolicy")),
 			mcp.WithString("namespace", mcp.Required(), mcp.Description("name of namespace")),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.PropagationPolicy]](),
+			withConfirmation(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			karmadaClient, err := getKarmadaClient(ctx)
@@ -189,22 +191,162 @@ func DeletePropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool
 				return nil, fmt.Errorf("failed to get Karmada client: %v", err)
 			}
 
-			paramName, ok := request.Params.Arguments["name"].(string)
+			paramName, ok := request.GetArguments()["name"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter name not found")
 			}
 
-			paramNamespace, ok := request.Params.Arguments["namespace"].(string)
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter namespace not found")
 			}
 
+			dryRun := parseDryRun(request)
+			if dryRun != nil {
+				live, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+				if err != nil {
+					klog.Errorf("get propagationpolicy error: %v", err)
+					return nil, err
+				}
+				if err = karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).Delete(ctx, paramName, metav1.DeleteOptions{DryRun: dryRun}); err != nil {
+					klog.ErrorS(err, "Failed to delete propagationpolicy")
+					return nil, err
+				}
+				return dryRunResult(live, nil)
+			}
+
 			err = karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).Delete(ctx, paramName, metav1.DeleteOptions{})
 			if err != nil {
 				klog.ErrorS(err, "Failed to delete propagationpolicy")
 				return nil, err
 			}
 
-			return mcp.NewToolResultText("delete propagationpolicy success"), nil
+			return structuredResult(writeResult[*v1alpha1.PropagationPolicy]{Message: "delete propagationpolicy success"})
+		}
+}
+
+func UpdatePropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"update_propagationpolicy",
+			mcp.WithDescription("Replace the spec of an existing propagationpolicy under the specific namespace in the Karmada control-plane without deleting it, returns the updated propagationpolicy and the diff of its spec"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for propagationpolicy")),
+			mcp.WithString("namespace", mcp.Required(), mcp.Description("namespace for propagationpolicy")),
+			mcp.WithString("content", mcp.Required(), mcp.Description("the propagationpolicy content which in form of yaml, its spec replaces the current one and its labels and annotations are added to the current ones")),
+			mcp.WithString("resourceVersion", mcp.Description("resourceVersion the content is based on, the update fails if the propagationpolicy has been changed since, overrides metadata.resourceVersion of the content. If both are empty the latest version is replaced")),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.PropagationPolicy]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			paramContent, ok := request.GetArguments()["content"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter content not found")
+			}
+			paramResourceVersion, _ := request.GetArguments()["resourceVersion"].(string)
+
+			propagationPolicy := v1alpha1.PropagationPolicy{}
+			if err = yaml.Unmarshal([]byte(paramContent), &propagationPolicy); err != nil {
+				klog.Errorf("unmarshal propagationpolicy error: %v", err)
+				return nil, err
+			}
+
+			current, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("get propagationpolicy error: %v", err)
+				return nil, err
+			}
+			updated := mergePolicyUpdate(current, &propagationPolicy, paramResourceVersion)
+			updated.Spec = propagationPolicy.Spec
+
+			return updatePropagationPolicy(ctx, karmadaClient, current, updated, parseDryRun(request))
 		}
 }
+
+func PatchPropagationPolicy(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"patch_propagationpolicy",
+			mcp.WithDescription("Patch an existing propagationpolicy under the specific namespace in the Karmada control-plane without deleting it, returns the updated propagationpolicy and the diff of its spec"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name for propagationpolicy")),
+			mcp.WithString("namespace", mcp.Required(), mcp.Description("namespace for propagationpolicy")),
+			mcp.WithString("patch", mcp.Required(), mcp.Description(`patch content in form of json, e.g. {"spec":{"priority":10}} for merge patch or [{"op":"replace","path":"/spec/priority","value":10}] for json patch`)),
+			mcp.WithString("patchType",
+				mcp.DefaultString(string(types.MergePatchType)),
+				mcp.Enum(string(types.MergePatchType), string(types.StrategicMergePatchType), string(types.JSONPatchType)),
+				mcp.Description("type of the patch: json merge patch, strategic merge patch or json patch"),
+			),
+			withDryRun(),
+			withOutputSchema[writeResult[*v1alpha1.PropagationPolicy]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			paramNamespace, ok := request.GetArguments()["namespace"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter namespace not found")
+			}
+
+			paramPatch, ok := request.GetArguments()["patch"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter patch not found")
+			}
+			paramPatchType := mcp.ParseString(request, "patchType", string(types.MergePatchType))
+
+			current, err := karmadaClient.PolicyV1alpha1().PropagationPolicies(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("get propagationpolicy error: %v", err)
+				return nil, err
+			}
+			original, err := json.Marshal(current)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal propagationpolicy: %w", err)
+			}
+
+			// The patch is applied locally and submitted as an update, so the result is only persisted
+			// when the propagationpolicy is unchanged since it was read. Strategic merge patch is
+			// not served by the apiserver for custom resources.
+			patched, err := applyPatch(types.PatchType(paramPatchType), original, []byte(paramPatch), &v1alpha1.PropagationPolicy{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply patch: %w", err)
+			}
+			propagationPolicy := v1alpha1.PropagationPolicy{}
+			if err = json.Unmarshal(patched, &propagationPolicy); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal patched propagationpolicy: %w", err)
+			}
+			propagationPolicy.Name = paramName
+			propagationPolicy.Namespace = paramNamespace
+			propagationPolicy.ResourceVersion = current.ResourceVersion
+
+			return updatePropagationPolicy(ctx, karmadaClient, current, &propagationPolicy, parseDryRun(request))
+		}
+}
+
+func updatePropagationPolicy(ctx context.Context, karmadaClient karmadaclientset.Interface, current, desired *v1alpha1.PropagationPolicy, dryRun []string) (*mcp.CallToolResult, error) {
+	return updatePolicy("propagationpolicy", current, desired, dryRun,
+		func(policy *v1alpha1.PropagationPolicy) (*v1alpha1.PropagationPolicy, error) {
+			return karmadaClient.PolicyV1alpha1().PropagationPolicies(policy.Namespace).Update(ctx, policy, metav1.UpdateOptions{DryRun: dryRun})
+		},
+		func(policy *v1alpha1.PropagationPolicy) interface{} { return policy.Spec },
+	)
+}
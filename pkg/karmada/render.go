@@ -0,0 +1,629 @@
+package karmada
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"io"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml/goyaml.v3"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Formats of the output parameter.
+const (
+	outputJSON    = "json"
+	outputYAML    = "yaml"
+	outputTable   = "table"
+	outputSummary = "summary"
+)
+
+// defaultSummaryTokens is the token budget of the summary output when maxTokens is not set.
+const defaultSummaryTokens = 1000
+
+// withOutput adds the output parameters shared by all tools, they are handled by renderOutput.
+func withOutput() mcp.ToolOption {
+	options := []mcp.ToolOption{
+		mcp.WithString("output",
+			mcp.Enum(outputJSON, outputYAML, outputTable, outputSummary),
+			mcp.Description("format of the text content of the result: json is compact JSON, yaml is YAML, table is a kubectl-like table of the items and summary is YAML shortened to fit maxTokens. managedFields are stripped from all of them. The result is returned unchanged if not set, the structured content always holds the full result"),
+		),
+		mcp.WithNumber("maxTokens", mcp.DefaultNumber(defaultSummaryTokens), mcp.Min(1), mcp.Description("approximate number of tokens the summary output is shortened to")),
+	}
+	return func(tool *mcp.Tool) {
+		for _, option := range options {
+			option(tool)
+		}
+	}
+}
+
+// renderOutput is the tool handler middleware rendering the results in the format of the output parameter.
+// The results are rendered from their structured content, or from their text content when it is JSON, other
+// results such as confirmations of writes are returned unchanged. The structured content is kept as is since
+// it has to match the output schema of the tool.
+func renderOutput(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// the parameters are validated before the call, a write must not be made and then reported as failed
+		format, _ := request.GetArguments()["output"].(string)
+		switch format {
+		case "", outputJSON, outputYAML, outputTable, outputSummary:
+		default:
+			return nil, fmt.Errorf("unknown output format %q, must be one of %s, %s, %s or %s", format, outputJSON, outputYAML, outputTable, outputSummary)
+		}
+		maxTokens := mcp.ParseInt(request, "maxTokens", defaultSummaryTokens)
+		if maxTokens < 1 {
+			return nil, fmt.Errorf("parameter maxTokens must be at least 1")
+		}
+
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError || format == "" {
+			return result, err
+		}
+		data, ok := resultData(result)
+		if !ok {
+			return result, nil
+		}
+		text, err := render(data, format, maxTokens)
+		if err != nil {
+			// the call succeeded, so its result is returned as is rather than an error
+			klog.Warningf("failed to render the result of tool %s as %s, err: %v", request.Params.Name, format, err)
+			return result, nil
+		}
+
+		rendered := *result
+		rendered.Content = []mcp.Content{mcp.NewTextContent(text)}
+		return &rendered, nil
+	}
+}
+
+// render renders data, which managedFields are stripped from, in the format.
+func render(data interface{}, format string, maxTokens int) (string, error) {
+	stripManagedFields(data)
+	switch format {
+	case outputJSON:
+		r, err := json.Marshal(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal result: %w", err)
+		}
+		return string(r), nil
+	case outputYAML:
+		return marshalYAML(data)
+	case outputTable:
+		return renderTable(data)
+	case outputSummary:
+		return renderSummary(data, maxTokens)
+	default:
+		return "", fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// resultData decodes the structured content of the result, or its text content when it is a JSON object or array.
+func resultData(result *mcp.CallToolResult) (interface{}, bool) {
+	var raw []byte
+	if result.StructuredContent != nil {
+		r, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return nil, false
+		}
+		raw = r
+	} else {
+		if len(result.Content) != 1 {
+			return nil, false
+		}
+		content, ok := mcp.AsTextContent(result.Content[0])
+		if !ok {
+			return nil, false
+		}
+		raw = []byte(content.Text)
+	}
+	data, err := decodeJSON(raw)
+	if err != nil {
+		return nil, false
+	}
+	switch data.(type) {
+	case *jsonObject, []interface{}:
+		return data, true
+	default:
+		return nil, false
+	}
+}
+
+// jsonObject is a decoded JSON object keeping the order of its fields, so that the rendered
+// results follow the order of the fields of the API types.
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o *jsonObject) get(key string) (interface{}, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+func (o *jsonObject) delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeJSON decodes raw into *jsonObject, []interface{}, string, json.Number, bool or nil values.
+func decodeJSON(raw []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+func decodeJSONValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	switch delim {
+	case '{':
+		object := &jsonObject{values: make(map[string]interface{})}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.keys = append(object.keys, key.(string))
+			object.values[key.(string)] = value
+		}
+		_, err = decoder.Token()
+		return object, err
+	case '[':
+		list := make([]interface{}, 0)
+		for decoder.More() {
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	default:
+		return nil, fmt.Errorf("unexpected delimiter %s", delim)
+	}
+}
+
+// stripManagedFields removes the managedFields of all the objects in data.
+func stripManagedFields(data interface{}) {
+	switch v := data.(type) {
+	case *jsonObject:
+		if metadata, ok := v.values["metadata"].(*jsonObject); ok {
+			metadata.delete("managedFields")
+		}
+		for _, value := range v.values {
+			stripManagedFields(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			stripManagedFields(value)
+		}
+	}
+}
+
+func marshalYAML(data interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(data)); err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func yamlNode(data interface{}) *yaml.Node {
+	switch v := data.(type) {
+	case *jsonObject:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range v.keys {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, yamlNode(v.values[key]))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, value := range v {
+			node.Content = append(node.Content, yamlNode(value))
+		}
+		return node
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}
+	default:
+		node := &yaml.Node{}
+		if err := node.Encode(v); err != nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}
+		}
+		return node
+	}
+}
+
+// listPageFields are the fields of the list results besides their items.
+var listPageFields = map[string]bool{"totalItems": true, "page": true, "nextCursor": true}
+
+// maxCellWidth is the width the table cells are truncated to.
+const maxCellWidth = 60
+
+// renderTable renders the items of a list result, or a single object, like kubectl get. Kubernetes objects are
+// shown with their namespace, name, kind and age, other items with their scalar fields.
+func renderTable(data interface{}) (string, error) {
+	rows := []interface{}{data}
+	var footer []string
+	switch v := data.(type) {
+	case []interface{}:
+		rows = v
+	case *jsonObject:
+		if items, ok := listItems(v); ok {
+			rows = items
+			for _, key := range v.keys {
+				if listPageFields[key] {
+					footer = append(footer, fmt.Sprintf("%s: %s", key, cellValue(v.values[key])))
+				}
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return strings.Join(append([]string{"No resources found."}, footer...), "\n"), nil
+	}
+
+	columns := tableColumns(rows)
+	buf := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buf, 10, 4, 3, ' ', 0)
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, column.header)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, truncate(column.value(row), maxCellWidth))
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed to render table: %w", err)
+	}
+	if len(footer) > 0 {
+		buf.WriteString("\n" + strings.Join(footer, "\n") + "\n")
+	}
+	return buf.String(), nil
+}
+
+// listItems returns the items of a list result, which has a single array field besides the page fields.
+func listItems(object *jsonObject) ([]interface{}, bool) {
+	var items []interface{}
+	found := false
+	for _, key := range object.keys {
+		if listPageFields[key] {
+			continue
+		}
+		list, ok := object.values[key].([]interface{})
+		if !ok || found {
+			return nil, false
+		}
+		items, found = list, true
+	}
+	return items, found
+}
+
+type tableColumn struct {
+	header string
+	value  func(row interface{}) string
+}
+
+func tableColumns(rows []interface{}) []tableColumn {
+	if _, ok := rows[0].(*jsonObject); !ok {
+		return []tableColumn{{header: "NAME", value: cellValue}}
+	}
+	if isKubernetesObject(rows[0]) {
+		return objectColumns(rows)
+	}
+
+	var columns []tableColumn
+	seen := map[string]bool{}
+	for _, row := range rows {
+		object, ok := row.(*jsonObject)
+		if !ok {
+			continue
+		}
+		for _, key := range object.keys {
+			if seen[key] || !isScalarOrScalars(object.values[key]) {
+				continue
+			}
+			seen[key] = true
+			key := key
+			columns = append(columns, tableColumn{header: columnHeader(key), value: func(row interface{}) string {
+				return fieldValue(row, key)
+			}})
+		}
+	}
+	return columns
+}
+
+// objectColumns are the columns of the Kubernetes objects, the namespace and kind are only shown when set.
+func objectColumns(rows []interface{}) []tableColumn {
+	hasNamespace, hasKind := false, false
+	for _, row := range rows {
+		hasNamespace = hasNamespace || fieldValue(row, "metadata", "namespace") != "<none>"
+		hasKind = hasKind || fieldValue(row, "kind") != "<none>"
+	}
+	var columns []tableColumn
+	if hasNamespace {
+		columns = append(columns, tableColumn{header: "NAMESPACE", value: func(row interface{}) string {
+			return fieldValue(row, "metadata", "namespace")
+		}})
+	}
+	columns = append(columns, tableColumn{header: "NAME", value: func(row interface{}) string {
+		return fieldValue(row, "metadata", "name")
+	}})
+	if hasKind {
+		columns = append(columns, tableColumn{header: "KIND", value: func(row interface{}) string {
+			return fieldValue(row, "kind")
+		}})
+	}
+	columns = append(columns, tableColumn{header: "AGE", value: func(row interface{}) string {
+		created, err := time.Parse(time.RFC3339, fieldValue(row, "metadata", "creationTimestamp"))
+		if err != nil {
+			return "<unknown>"
+		}
+		return duration.HumanDuration(time.Since(created))
+	}})
+	return columns
+}
+
+func isKubernetesObject(row interface{}) bool {
+	object, ok := row.(*jsonObject)
+	if !ok {
+		return false
+	}
+	metadata, ok := object.values["metadata"].(*jsonObject)
+	if !ok {
+		return false
+	}
+	_, ok = metadata.get("name")
+	return ok
+}
+
+func isScalarOrScalars(value interface{}) bool {
+	switch v := value.(type) {
+	case *jsonObject:
+		return false
+	case []interface{}:
+		for _, item := range v {
+			if !isScalarOrScalars(item) {
+				return false
+			}
+			if _, ok := item.([]interface{}); ok {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+func fieldValue(row interface{}, path ...string) string {
+	value := row
+	for _, key := range path {
+		object, ok := value.(*jsonObject)
+		if !ok {
+			return "<none>"
+		}
+		if value, ok = object.get(key); !ok {
+			return "<none>"
+		}
+	}
+	return cellValue(value)
+}
+
+func cellValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		if v == "" {
+			return "<none>"
+		}
+		return v
+	case []interface{}:
+		if len(v) == 0 {
+			return "<none>"
+		}
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, cellValue(item))
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// columnHeader turns a field name into a kubectl-like header, e.g. kubernetesVersion into KUBERNETES VERSION.
+func columnHeader(key string) string {
+	header := strings.Builder{}
+	var previous rune
+	for _, r := range key {
+		if unicode.IsUpper(r) && unicode.IsLower(previous) {
+			header.WriteRune(' ')
+		}
+		header.WriteRune(unicode.ToUpper(r))
+		previous = r
+	}
+	return header.String()
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width-3]) + "..."
+}
+
+// summaryLevel bounds the lists, strings and nesting of a summary, zero values do not bound them.
+type summaryLevel struct {
+	maxItems  int
+	maxString int
+	maxDepth  int
+}
+
+// summaryLevels are tried in order until the summary fits in its token budget.
+var summaryLevels = []summaryLevel{
+	{},
+	{maxItems: 20, maxString: 200, maxDepth: 8},
+	{maxItems: 10, maxString: 100, maxDepth: 6},
+	{maxItems: 5, maxString: 80, maxDepth: 4},
+	{maxItems: 3, maxString: 60, maxDepth: 3},
+	{maxItems: 1, maxString: 40, maxDepth: 2},
+}
+
+// lastAppliedAnnotation holds a copy of the object applied by kubectl, it only doubles the size of the summary.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// renderSummary renders data as YAML shortened until it fits in about maxTokens tokens, counting 4 characters
+// per token. Long lists and strings are cut and deep fields collapsed, with markers of what was left out.
+func renderSummary(data interface{}, maxTokens int) (string, error) {
+	stripSummaryNoise(data)
+	maxLength := maxTokens * 4
+	var text string
+	for i, level := range summaryLevels {
+		summary, err := marshalYAML(summarize(data, level, 0))
+		if err != nil {
+			return "", err
+		}
+		text = summary
+		if len(text) <= maxLength {
+			if i > 0 {
+				text = fmt.Sprintf("# shortened to about %d tokens, use the yaml output for the full result\n%s", maxTokens, text)
+			}
+			return text, nil
+		}
+	}
+	cut := []rune(text)
+	if len(cut) > maxLength {
+		cut = cut[:maxLength]
+	}
+	return fmt.Sprintf("# cut at about %d tokens, use the yaml output for the full result\n%s\n...", maxTokens, string(cut)), nil
+}
+
+// stripSummaryNoise removes the metadata fields a summary does not need.
+func stripSummaryNoise(data interface{}) {
+	switch v := data.(type) {
+	case *jsonObject:
+		if metadata, ok := v.values["metadata"].(*jsonObject); ok {
+			for _, key := range []string{"uid", "resourceVersion", "generation", "selfLink"} {
+				metadata.delete(key)
+			}
+			if annotations, ok := metadata.values["annotations"].(*jsonObject); ok {
+				annotations.delete(lastAppliedAnnotation)
+			}
+		}
+		for _, value := range v.values {
+			stripSummaryNoise(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			stripSummaryNoise(value)
+		}
+	}
+}
+
+func summarize(data interface{}, level summaryLevel, depth int) interface{} {
+	switch v := data.(type) {
+	case *jsonObject:
+		if level.maxDepth > 0 && depth >= level.maxDepth {
+			return fmt.Sprintf("{%d fields}", len(v.keys))
+		}
+		object := &jsonObject{keys: v.keys, values: make(map[string]interface{}, len(v.values))}
+		for key, value := range v.values {
+			object.values[key] = summarize(value, level, depth+1)
+		}
+		return object
+	case []interface{}:
+		if level.maxDepth > 0 && depth >= level.maxDepth {
+			return fmt.Sprintf("[%d items]", len(v))
+		}
+		items := v
+		if level.maxItems > 0 && len(items) > level.maxItems {
+			items = items[:level.maxItems]
+		}
+		list := make([]interface{}, 0, len(items)+1)
+		for _, item := range items {
+			list = append(list, summarize(item, level, depth+1))
+		}
+		if len(items) < len(v) {
+			list = append(list, fmt.Sprintf("... %d more items", len(v)-len(items)))
+		}
+		return list
+	case string:
+		if level.maxString > 0 {
+			return truncate(v, level.maxString)
+		}
+		return v
+	default:
+		return v
+	}
+}
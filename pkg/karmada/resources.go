@@ -0,0 +1,154 @@
+package karmada
+
+import (
+	"context"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+const resourceMIMEType = "application/yaml"
+
+// ObjectResource returns a resource template for the objects of a resource, the object is namespaced if the template has a namespace variable.
+func ObjectResource(uriTemplate, name, description string, gvr schema.GroupVersionResource, getDynamicClient GetDynamicClientFn) (template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			uriTemplate,
+			name,
+			mcp.WithTemplateDescription(description+", returned as yaml"),
+			mcp.WithTemplateMIMEType(resourceMIMEType),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			dynamicClient, err := getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+			}
+
+			obj, err := dynamicClient.Resource(gvr).Namespace(resourceArgument(request, "namespace")).Get(ctx, resourceArgument(request, "name"), metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get %s for resource %s, err: %v", gvr.Resource, request.Params.URI, err)
+				return nil, err
+			}
+			return yamlResourceContents(request.Params.URI, obj)
+		}
+}
+
+// GenericResource returns a resource template for any kind of resource in the Karmada control-plane.
+func GenericResource(mapper meta.RESTMapper, getDynamicClient GetDynamicClientFn) (template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"karmada://resources/{group}/{version}/{kind}/{namespace}/{name}",
+			"resource",
+			mcp.WithTemplateDescription("Any kind of resource in the Karmada control-plane, returned as yaml. "+
+				"group is empty or core for the core group, namespace is empty for cluster-scoped resources, "+
+				"e.g. karmada://resources/apps/v1/Deployment/default/nginx or karmada://resources/core/v1/Namespace//default"),
+			mcp.WithTemplateMIMEType(resourceMIMEType),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			dynamicClient, err := getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+			}
+
+			group := resourceArgument(request, "group")
+			if group == "core" {
+				group = ""
+			}
+			apiVersion := schema.GroupVersion{Group: group, Version: resourceArgument(request, "version")}.String()
+			kind := resourceArgument(request, "kind")
+			namespace := resourceArgument(request, "namespace")
+
+			resource, namespaced, err := resourceClient(mapper, dynamicClient, apiVersion, kind)
+			if err != nil {
+				return nil, err
+			}
+			if !namespaced {
+				namespace = ""
+			} else if namespace == "" {
+				return nil, fmt.Errorf("namespace is required for namespace-scoped kind %s", kind)
+			}
+			obj, err := resource.Namespace(namespace).Get(ctx, resourceArgument(request, "name"), metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get %s for resource %s, err: %v", kind, request.Params.URI, err)
+				return nil, err
+			}
+			return yamlResourceContents(request.Params.URI, obj)
+		}
+}
+
+// resourceToolCall returns the get tool equivalent to reading the resource at uri and its call, so that the tool
+// policy and the audit log treat resource reads like the tool calls, e.g. karmada://resources/core/v1/Secret/default/token
+// is read like get_resource with the apiVersion v1, kind Secret, namespace default and name token.
+// The returned tool declares the arguments of the call, which are parsed from the uri rather than passed by the client.
+func resourceToolCall(uri string) (mcp.Tool, mcp.CallToolRequest) {
+	request := mcp.CallToolRequest{}
+	arguments := map[string]interface{}{}
+	if path, ok := strings.CutPrefix(uri, "karmada://resources/"); ok {
+		if parts := strings.SplitN(path, "/", 5); len(parts) == 5 {
+			group := parts[0]
+			if group == "core" {
+				group = ""
+			}
+			request.Params.Name = "get_resource"
+			arguments["apiVersion"] = schema.GroupVersion{Group: group, Version: parts[1]}.String()
+			arguments["kind"] = parts[2]
+			arguments["namespace"] = parts[3]
+			arguments["name"] = parts[4]
+		}
+	}
+	for _, resource := range subscribedResources {
+		path, ok := strings.CutPrefix(uri, resource.uriPrefix)
+		if !ok {
+			continue
+		}
+		request.Params.Name = "get_" + strings.ToLower(resource.kind)
+		if resource.namespaced {
+			arguments["namespace"], arguments["name"], _ = strings.Cut(path, "/")
+		} else {
+			arguments["name"] = path
+		}
+	}
+	if arguments["namespace"] == "" {
+		delete(arguments, "namespace")
+	}
+	request.Params.Arguments = arguments
+	options := make([]mcp.ToolOption, 0, len(arguments))
+	for name := range arguments {
+		options = append(options, mcp.WithString(name))
+	}
+	return mcp.NewTool(request.Params.Name, options...), request
+}
+
+// resourceArgument returns a variable of the resource template matched by the uri, the variables are
+// passed as the values matched by the uri template.
+func resourceArgument(request mcp.ReadResourceRequest, name string) string {
+	switch value := request.Params.Arguments[name].(type) {
+	case string:
+		return value
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+	}
+	return ""
+}
+
+func yamlResourceContents(uri string, obj *unstructured.Unstructured) ([]mcp.ResourceContents, error) {
+	obj.SetManagedFields(nil)
+	content, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", obj.GetKind(), err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: resourceMIMEType,
+			Text:     string(content),
+		},
+	}, nil
+}
@@ -6,7 +6,13 @@ import (
 	"github.com/karmada-io/dashboard/pkg/client"
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/warjiang/karmada-mcp-server/pkg/audit"
+	"github.com/warjiang/karmada-mcp-server/pkg/toolpolicy"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"time"
 )
 
 // NewServer creates a new GitHub MCP server with the specified GH client and logger.
@@ -28,7 +34,9 @@ func NewServer(version string, opts ...server.ServerOption) *server.MCPServer {
 	return s
 }
 
-func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
+// NewMCPServer creates the Karmada MCP server, the transports pass their messages through the returned
+// subscriptions so that resources/subscribe is served.
+func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, *Subscriptions, error) {
 	// init karmada client
 	// init kubernetes client
 
@@ -62,32 +70,132 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		})
 	*/
 
+	addMetricsHooks(hooks)
+
+	subscriptions := newSubscriptions()
+	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+		subscriptions.addSession(session.SessionID())
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		subscriptions.removeSession(session.SessionID())
+	})
+
+	auditLogger, err := audit.NewLogger(cfg.Audit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create audit logger: %w", err)
+	}
+	if auditLogger != nil {
+		addAuditHooks(hooks, auditLogger)
+	}
+
+	// The output middleware is the outermost one, so that it renders the results of the other middlewares too
+	serverOpts := []server.ServerOption{
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(renderOutput),
+	}
+	if cfg.ToolPolicyFile != "" {
+		policy, err := toolpolicy.Load(cfg.ToolPolicyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		// BeforeCallTool hooks can not reject a call, so the policy is enforced by a tool handler middleware
+		serverOpts = append(serverOpts,
+			server.WithToolHandlerMiddleware(policy.Middleware),
+			server.WithResourceHandlerMiddleware(policy.ResourceMiddleware(resourceToolCall)),
+		)
+	}
+	// The confirmation middleware is added after the policy one, so that denied calls are not confirmed first
+	switch cfg.Confirmation {
+	case "", ConfirmationNone:
+	case ConfirmationAuto, ConfirmationToken:
+		ttl := cfg.ConfirmationTTL
+		if ttl <= 0 {
+			ttl = 5 * time.Minute
+		}
+		serverOpts = append(serverOpts,
+			server.WithElicitation(),
+			server.WithToolHandlerMiddleware(newConfirmer(cfg.Confirmation, ttl).middleware),
+		)
+	default:
+		return nil, nil, fmt.Errorf("unknown confirmation mode %q", cfg.Confirmation)
+	}
+
 	// Create karmada MCP server
-	karmadaServer := NewServer(cfg.Version, server.WithHooks(hooks))
+	karmadaServer := NewServer(cfg.Version, serverOpts...)
+	subscriptions.server = karmadaServer
+
+	karmadaConfig, _, err := client.GetKarmadaConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get Karmada config: %w", err)
+	}
 
+	// The shared clients act with the identity of the server, calls carrying the credentials of
+	// the MCP client get clients built for them, so Karmada RBAC and audit see the real caller.
 	karmadaClient := client.InClusterKarmadaClient()
-	getKarmadaClient := func(_ context.Context) (karmadaclientset.Interface, error) {
+	getKarmadaClient := func(ctx context.Context) (karmadaclientset.Interface, error) {
+		if config, ok := configForContext(ctx, karmadaConfig); ok {
+			return karmadaclientset.NewForConfig(config)
+		}
 		return karmadaClient, nil // closing over client
 	}
 
 	k8sClient := client.InClusterClientForKarmadaAPIServer()
-	getKubernetesClient := func(_ context.Context) (kubernetes.Interface, error) {
+	getKubernetesClient := func(ctx context.Context) (kubernetes.Interface, error) {
+		if config, ok := configForContext(ctx, karmadaConfig); ok {
+			return kubernetes.NewForConfig(config)
+		}
 		return k8sClient, nil // closing over client
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(karmadaConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	getDynamicClient := func(ctx context.Context) (dynamic.Interface, error) {
+		if config, ok := configForContext(ctx, karmadaConfig); ok {
+			return dynamic.NewForConfig(config)
+		}
+		return dynamicClient, nil // closing over client
+	}
+
+	// discovery is shared by all calls, the requests mapped with it are still made with the clients of the caller
+	mapper := newRESTMapper(k8sClient.Discovery())
+
 	enabledToolsets := cfg.EnabledToolsets
+	if cfg.DynamicToolsets {
+		// toolsets are enabled on demand, only the ones explicitly requested are enabled at startup
+		enabledToolsets = make([]string, 0, len(cfg.EnabledToolsets))
+		for _, name := range cfg.EnabledToolsets {
+			if name != "all" {
+				enabledToolsets = append(enabledToolsets, name)
+			}
+		}
+	}
 	// Create default toolsets
 	toolsets, err := InitToolsetGroup(
 		enabledToolsets,
 		cfg.ReadOnly,
-		getKarmadaClient, getKubernetesClient,
+		getKarmadaClient, getKubernetesClient, getDynamicClient, mapper,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize toolsets: %w", err)
+		return nil, nil, fmt.Errorf("failed to initialize toolsets: %w", err)
 	}
 
-	// Register the tools with the server
+	// Register the tools and resource templates of the enabled toolsets with the server, the resources
+	// are only served with the toolset of the get tools reading the same objects
 	toolsets.RegisterTools(karmadaServer)
+	if cfg.DynamicToolsets {
+		InitDynamicToolset(karmadaServer, toolsets).RegisterTools(karmadaServer)
+	}
+
+	metadataClient, err := metadata.NewForConfig(karmadaConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create metadata client: %w", err)
+	}
+	// the informers run as long as the process, like the server
+	if err := subscriptions.start(metadataClient, wait.NeverStop); err != nil {
+		return nil, nil, err
+	}
 
-	return karmadaServer, nil
+	return karmadaServer, subscriptions, nil
 }
@@ -0,0 +1,258 @@
+package karmada
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"net/http"
+	"sync"
+)
+
+const (
+	// stdioSessionID is the id mcp-go gives to the single session of the stdio transport.
+	stdioSessionID = "stdio"
+
+	methodResourcesSubscribe   mcp.MCPMethod = "resources/subscribe"
+	methodResourcesUnsubscribe mcp.MCPMethod = "resources/unsubscribe"
+)
+
+// subscribedResource is a kind of Karmada object watched for the subscriptions, with the uri template
+// it is published with by the toolsets.
+type subscribedResource struct {
+	gvr        schema.GroupVersionResource
+	kind       string
+	uriPrefix  string
+	namespaced bool
+}
+
+var subscribedResources = []subscribedResource{
+	{clusterv1alpha1.SchemeGroupVersion.WithResource("clusters"), "Cluster", "karmada://clusters/", false},
+	{policyv1alpha1.SchemeGroupVersion.WithResource("propagationpolicies"), "PropagationPolicy", "karmada://propagationpolicies/", true},
+	{policyv1alpha1.SchemeGroupVersion.WithResource("clusterpropagationpolicies"), "ClusterPropagationPolicy", "karmada://clusterpropagationpolicies/", false},
+	{policyv1alpha1.SchemeGroupVersion.WithResource("overridepolicies"), "OverridePolicy", "karmada://overridepolicies/", true},
+	{policyv1alpha1.SchemeGroupVersion.WithResource("clusteroverridepolicies"), "ClusterOverridePolicy", "karmada://clusteroverridepolicies/", false},
+	{workv1alpha2.SchemeGroupVersion.WithResource("resourcebindings"), "ResourceBinding", "karmada://resourcebindings/", true},
+	{workv1alpha2.SchemeGroupVersion.WithResource("clusterresourcebindings"), "ClusterResourceBinding", "karmada://clusterresourcebindings/", false},
+}
+
+// uris returns the uris an object is published with, the one of its own template and the one of the generic template.
+func (r subscribedResource) uris(namespace, name string) []string {
+	uri := r.uriPrefix + name
+	if r.namespaced {
+		uri = r.uriPrefix + namespace + "/" + name
+	}
+	return []string{
+		uri,
+		fmt.Sprintf("karmada://resources/%s/%s/%s/%s/%s", r.gvr.Group, r.gvr.Version, r.kind, namespace, name),
+	}
+}
+
+// Subscriptions keeps the resources subscribed by every session and sends them notifications/resources/updated
+// when a subscribed cluster, policy or binding changes. The objects are watched by shared informers with the
+// identity of the server, only the uri is sent to the clients, which read the resource with their own credentials.
+//
+// mcp-go does not dispatch resources/subscribe and resources/unsubscribe, so the transports pass their messages
+// through FilterMessage, Reader or Handler before the MCP server handles them.
+type Subscriptions struct {
+	server *server.MCPServer
+
+	lock sync.RWMutex
+	// sessions maps the ids of the sessions registered on the MCP server to the uris subscribed by the session,
+	// the session ids of the messages are not validated yet when they are filtered
+	sessions map[string]map[string]struct{}
+}
+
+func newSubscriptions() *Subscriptions {
+	return &Subscriptions{
+		sessions: make(map[string]map[string]struct{}),
+	}
+}
+
+// start watches the subscribed resources until stopCh is closed, the caches are not waited for
+// since an unreachable apiserver should not prevent the server from starting. The objects listed
+// when the informers start are notified as added, but no session has subscribed yet.
+func (s *Subscriptions) start(metadataClient metadata.Interface, stopCh <-chan struct{}) error {
+	factory := metadatainformer.NewSharedInformerFactory(metadataClient, 0)
+	for _, resource := range subscribedResources {
+		resource := resource
+		notify := func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			object, ok := obj.(*metav1.PartialObjectMetadata)
+			if !ok {
+				return
+			}
+			for _, uri := range resource.uris(object.Namespace, object.Name) {
+				s.notify(uri)
+			}
+		}
+		_, err := factory.ForResource(resource.gvr).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: notify,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldObject, oldOK := oldObj.(*metav1.PartialObjectMetadata)
+				newObject, newOK := newObj.(*metav1.PartialObjectMetadata)
+				if oldOK && newOK && oldObject.ResourceVersion == newObject.ResourceVersion {
+					return
+				}
+				notify(newObj)
+			},
+			DeleteFunc: notify,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to watch %s: %w", resource.gvr.Resource, err)
+		}
+	}
+	factory.Start(stopCh)
+	return nil
+}
+
+// Subscribe records that the session wants to be notified of the changes of the resource, it is ignored
+// unless the session is registered on the MCP server, so that made up session ids do not accumulate.
+func (s *Subscriptions) Subscribe(sessionID, uri string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if uris, ok := s.sessions[sessionID]; ok {
+		uris[uri] = struct{}{}
+	}
+}
+
+// Unsubscribe stops notifying the session of the changes of the resource.
+func (s *Subscriptions) Unsubscribe(sessionID, uri string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.sessions[sessionID], uri)
+}
+
+// addSession allows a session registered on the MCP server to subscribe.
+func (s *Subscriptions) addSession(sessionID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.sessions[sessionID]; !ok {
+		s.sessions[sessionID] = make(map[string]struct{})
+	}
+}
+
+// removeSession drops the subscriptions of a session unregistered from the MCP server.
+func (s *Subscriptions) removeSession(sessionID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+func (s *Subscriptions) notify(uri string) {
+	s.lock.RLock()
+	var sessionIDs []string
+	for sessionID, uris := range s.sessions {
+		if _, ok := uris[uri]; ok {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	s.lock.RUnlock()
+
+	for _, sessionID := range sessionIDs {
+		err := s.server.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		if err != nil {
+			klog.V(4).Infof("failed to notify session %s of the update of %s, err: %v", sessionID, uri, err)
+		}
+	}
+}
+
+// FilterMessage records the resources/subscribe and resources/unsubscribe requests of the session and returns
+// the message to pass to the MCP server. Such requests are replaced by a ping with the same id, so that the
+// server answers them with the empty result the protocol expects. Other messages are returned unchanged.
+func (s *Subscriptions) FilterMessage(sessionID string, message []byte) []byte {
+	var request struct {
+		ID     json.RawMessage `json:"id"`
+		Method mcp.MCPMethod   `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || len(request.ID) == 0 {
+		return message
+	}
+	switch request.Method {
+	case methodResourcesSubscribe, methodResourcesUnsubscribe:
+	default:
+		return message
+	}
+	// requests without uri are left to the server, which rejects them
+	if request.Params.URI == "" {
+		return message
+	}
+	if sessionID != "" {
+		if request.Method == methodResourcesSubscribe {
+			s.Subscribe(sessionID, request.Params.URI)
+		} else {
+			s.Unsubscribe(sessionID, request.Params.URI)
+		}
+	}
+	ping, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      request.ID,
+		"method":  mcp.MethodPing,
+	})
+	if err != nil {
+		return message
+	}
+	return ping
+}
+
+// Reader returns a reader filtering the messages of the stdio transport read from r.
+func (s *Subscriptions) Reader(r io.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if _, writeErr := pw.Write(append(s.FilterMessage(stdioSessionID, bytes.TrimSpace(line)), '\n')); writeErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
+
+// Handler filters the messages posted to the SSE and Streamable HTTP transports before passing them to next.
+func (s *Subscriptions) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		// the Streamable HTTP transport sends the session in a header, the SSE one in the query
+		sessionID := r.Header.Get(server.HeaderKeySessionID)
+		if sessionID == "" {
+			sessionID = r.URL.Query().Get("sessionId")
+		}
+		body = s.FilterMessage(sessionID, body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -2,8 +2,14 @@ package karmada
 
 import (
 	"context"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"github.com/mark3labs/mcp-go/mcp"
 	toolsets "github.com/warjiang/karmada-mcp-server/pkg/toolset"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -11,9 +17,21 @@ type GetKarmadaClientFn func(context.Context) (karmadaclientset.Interface, error
 
 type GetKubernetesClientFn func(context.Context) (kubernetes.Interface, error)
 
+type GetDynamicClientFn func(context.Context) (dynamic.Interface, error)
+
 var DefaultTools = []string{"all"}
 
-func InitToolsetGroup(passedToolsets []string, readOnly bool, getKarmadaClient GetKarmadaClientFn, getKubernetesClient GetKubernetesClientFn) (*toolsets.ToolsetGroup, error) {
+// Annotation overrides of the write tools, which are annotated as destructive and not idempotent by default.
+var (
+	// additive marks tools which only create objects or lift restrictions
+	additive = mcp.WithDestructiveHintAnnotation(false)
+	// idempotent marks tools whose repeated calls with the same arguments have no additional effect
+	idempotent = mcp.WithIdempotentHintAnnotation(true)
+	// openWorld marks tools reaching out of the Karmada control-plane, e.g. to a member cluster
+	openWorld = mcp.WithOpenWorldHintAnnotation(true)
+)
+
+func InitToolsetGroup(passedToolsets []string, readOnly bool, getKarmadaClient GetKarmadaClientFn, getKubernetesClient GetKubernetesClientFn, getDynamicClient GetDynamicClientFn, mapper meta.RESTMapper) (*toolsets.ToolsetGroup, error) {
 	// Create a new toolset group
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
@@ -22,31 +40,113 @@ func InitToolsetGroup(passedToolsets []string, readOnly bool, getKarmadaClient G
 	clusters := toolsets.NewToolset("cluster", "Karmada cluster related tools").
 		AddReadTools(
 			toolsets.NewServerTool(ListClusters(getKarmadaClient)),
+			toolsets.NewServerTool(GetCluster(getKarmadaClient)),
 		).
-		AddWriteTools()
+		AddWriteTools(
+			toolsets.NewServerTool(JoinCluster(getKarmadaClient, getKubernetesClient)),
+			toolsets.NewServerTool(UnjoinCluster(getKarmadaClient)),
+			toolsets.NewServerTool(TaintCluster(getKarmadaClient)),
+			toolsets.NewServerTool(UntaintCluster(getKarmadaClient)),
+			toolsets.NewServerTool(CordonCluster(getKarmadaClient)),
+			toolsets.NewServerTool(UncordonCluster(getKarmadaClient)),
+		).
+		OverrideAnnotations("join_cluster", additive, openWorld).
+		OverrideAnnotations("unjoin_cluster", idempotent).
+		OverrideAnnotations("taint_cluster", idempotent).
+		OverrideAnnotations("untaint_cluster", additive, idempotent).
+		// cordoning only stops new scheduling, and a cordoned cluster keeps its taint when cordoned again
+		OverrideAnnotations("cordon_cluster", additive, idempotent).
+		OverrideAnnotations("uncordon_cluster", additive, idempotent).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(ObjectResource("karmada://clusters/{name}", "cluster",
+				"A member cluster registered in Karmada", clusterv1alpha1.SchemeGroupVersion.WithResource("clusters"), getDynamicClient)),
+		)
 	policies := toolsets.NewToolset("policy", "Karmada policy related tools").
 		AddReadTools(
-			toolsets.NewServerTool(ListPropagationPolicy(getKarmadaClient, getKubernetesClient)),
+			toolsets.NewServerTool(ListPropagationPolicy(getKarmadaClient)),
 			toolsets.NewServerTool(GetPropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(ListClusterPropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(GetClusterPropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(ListOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(GetOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(ListClusterOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(GetClusterOverridePolicy(getKarmadaClient)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreatePropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(UpdatePropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(PatchPropagationPolicy(getKarmadaClient)),
 			toolsets.NewServerTool(DeletePropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(CreateClusterPropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(UpdateClusterPropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(DeleteClusterPropagationPolicy(getKarmadaClient)),
+			toolsets.NewServerTool(CreateOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(UpdateOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(DeleteOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(CreateClusterOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(UpdateClusterOverridePolicy(getKarmadaClient)),
+			toolsets.NewServerTool(DeleteClusterOverridePolicy(getKarmadaClient)),
+		).
+		OverrideAnnotations("create_propagationpolicy", additive).
+		OverrideAnnotations("update_propagationpolicy", idempotent).
+		OverrideAnnotations("delete_propagationpolicy", idempotent).
+		OverrideAnnotations("create_clusterpropagationpolicy", additive).
+		OverrideAnnotations("update_clusterpropagationpolicy", idempotent).
+		OverrideAnnotations("delete_clusterpropagationpolicy", idempotent).
+		OverrideAnnotations("create_overridepolicy", additive).
+		OverrideAnnotations("update_overridepolicy", idempotent).
+		OverrideAnnotations("delete_overridepolicy", idempotent).
+		OverrideAnnotations("create_clusteroverridepolicy", additive).
+		OverrideAnnotations("update_clusteroverridepolicy", idempotent).
+		OverrideAnnotations("delete_clusteroverridepolicy", idempotent).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(ObjectResource("karmada://propagationpolicies/{namespace}/{name}", "propagationpolicy",
+				"A propagationpolicy in the Karmada control-plane", policyv1alpha1.SchemeGroupVersion.WithResource("propagationpolicies"), getDynamicClient)),
+			toolsets.NewServerResourceTemplate(ObjectResource("karmada://clusterpropagationpolicies/{name}", "clusterpropagationpolicy",
+				"A clusterpropagationpolicy in the Karmada control-plane", policyv1alpha1.SchemeGroupVersion.WithResource("clusterpropagationpolicies"), getDynamicClient)),
+			toolsets.NewServerResourceTemplate(ObjectResource("karmada://overridepolicies/{namespace}/{name}", "overridepolicy",
+				"An overridepolicy in the Karmada control-plane", policyv1alpha1.SchemeGroupVersion.WithResource("overridepolicies"), getDynamicClient)),
+			toolsets.NewServerResourceTemplate(ObjectResource("karmada://clusteroverridepolicies/{name}", "clusteroverridepolicy",
+				"A clusteroverridepolicy in the Karmada control-plane", policyv1alpha1.SchemeGroupVersion.WithResource("clusteroverridepolicies"), getDynamicClient)),
 		)
 	resources := toolsets.NewToolset("resource", "Karmada resource related tools").
 		AddReadTools(
 			toolsets.NewServerTool(ListNamespace(getKubernetesClient)),
 			toolsets.NewServerTool(ListDeployment(getKubernetesClient)),
+			toolsets.NewServerTool(GetResource(mapper, getDynamicClient)),
+			toolsets.NewServerTool(ListResources(mapper, getDynamicClient)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateNamespace(getKubernetesClient)),
 			toolsets.NewServerTool(CreateDeployment(getKubernetesClient)),
-			toolsets.NewServerTool(DeleteUnstructuredResource()),
+			toolsets.NewServerTool(ApplyResource(mapper, getDynamicClient)),
+			toolsets.NewServerTool(DeleteUnstructuredResource(mapper, getDynamicClient)),
+		).
+		OverrideAnnotations("create_namespace", additive).
+		OverrideAnnotations("create_deployment", additive).
+		OverrideAnnotations("apply_resource", idempotent).
+		OverrideAnnotations("delete_unstructured_resource", idempotent).
+		AddResourceTemplates(toolsets.NewServerResourceTemplate(GenericResource(mapper, getDynamicClient)))
+	propagations := toolsets.NewToolset("propagation", "Karmada propagation status related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListResourceBinding(getKarmadaClient)),
+			toolsets.NewServerTool(GetResourceBinding(getKarmadaClient)),
+			toolsets.NewServerTool(ListClusterResourceBinding(getKarmadaClient)),
+			toolsets.NewServerTool(GetClusterResourceBinding(getKarmadaClient)),
+			toolsets.NewServerTool(ListWork(getKarmadaClient)),
+			toolsets.NewServerTool(GetWork(getKarmadaClient)),
+			toolsets.NewServerTool(ExplainPropagation(getKarmadaClient, mapper, getDynamicClient)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(ObjectResource("karmada://resourcebindings/{namespace}/{name}", "resourcebinding",
+				"A resourcebinding with the scheduling result and aggregated status of a namespaced resource", workv1alpha2.SchemeGroupVersion.WithResource("resourcebindings"), getDynamicClient)),
+			toolsets.NewServerResourceTemplate(ObjectResource("karmada://clusterresourcebindings/{name}", "clusterresourcebinding",
+				"A clusterresourcebinding with the scheduling result and aggregated status of a cluster-scoped resource", workv1alpha2.SchemeGroupVersion.WithResource("clusterresourcebindings"), getDynamicClient)),
 		)
-	// Add toolsets to the group
-	tsg.AddToolset(clusters)
-	tsg.AddToolset(policies)
-	tsg.AddToolset(resources)
+	// Add toolsets to the group, every tool accepts the output parameter rendered by renderOutput
+	for _, toolset := range []*toolsets.Toolset{clusters, policies, resources, propagations} {
+		tsg.AddToolset(toolset.AddToolOptions(withOutput()))
+	}
 
 	// Enable the requested features
 	if err := tsg.EnableToolsets(passedToolsets); err != nil {
@@ -3,15 +3,39 @@ package karmada
 import (
 	"context"
 	"fmt"
-	"github.com/karmada-io/dashboard/pkg/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+	"time"
 )
 
-func DeleteUnstructuredResource() (tool mcp.Tool, handler server.ToolHandlerFunc) {
+// fieldManager is the field manager recorded for changes made through server-side apply.
+const fieldManager = "karmada-mcp-server"
+
+// resourceClient resolves apiVersion and kind to a resource through the discovery of the Karmada apiserver,
+// and reports whether the resource is namespace-scoped.
+func resourceClient(mapper meta.RESTMapper, dynamicClient dynamic.Interface, apiVersion, kind string) (dynamic.NamespaceableResourceInterface, bool, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+	mapping, err := mapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find resource for %s %s: %w", apiVersion, kind, err)
+	}
+	return dynamicClient.Resource(mapping.Resource), mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+func DeleteUnstructuredResource(mapper meta.RESTMapper, getDynamicClient GetDynamicClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"delete_unstructured_resource",
 			mcp.WithDescription("Delete unstructured resources in the Karmada control-plane"),
@@ -29,50 +53,273 @@ func DeleteUnstructuredResource() (tool mcp.Tool, handler server.ToolHandlerFunc
 			mcp.WithBoolean("deleteNow",
 				mcp.DefaultBool(true),
 				mcp.Description("whether waiting for resources be deleted successfully")),
+			withDryRun(),
+			withOutputSchema[writeResult[*kubernetesObject]](),
+			withConfirmation(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			// todo update by karmada-dashboard
-			verber, err := client.VerberClient(nil)
+			dynamicClient, err := getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+			}
 
-			paramNamespace, _ := request.Params.Arguments["namespace"].(string)
-			paramKind, ok := request.Params.Arguments["kind"].(string)
+			paramNamespace, _ := request.GetArguments()["namespace"].(string)
+			paramKind, ok := request.GetArguments()["kind"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter kind not found")
 			}
-			paramName, ok := request.Params.Arguments["name"].(string)
+			paramName, ok := request.GetArguments()["name"].(string)
 			if !ok {
 				return nil, fmt.Errorf("parameter name not found")
 			}
-			paramDeleteNow, ok := request.Params.Arguments["deleteNow"].(bool)
+			paramDeleteNow, ok := request.GetArguments()["deleteNow"].(bool)
 			if !ok {
 				return nil, fmt.Errorf("parameter deleteNow not found")
 			}
 
-			if err = verber.Delete(paramKind, paramNamespace, paramName, paramDeleteNow); err != nil {
+			// the resource is deleted with the clients of the caller, so that Karmada RBAC and audit apply to them
+			gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Resource: paramKind})
+			if err != nil {
+				return nil, fmt.Errorf("failed to find resource for %s: %w", paramKind, err)
+			}
+			var resource dynamic.ResourceInterface = dynamicClient.Resource(gvr)
+			if paramNamespace != "" {
+				resource = dynamicClient.Resource(gvr).Namespace(paramNamespace)
+			}
+
+			if dryRun := parseDryRun(request); dryRun != nil {
+				live, err := resource.Get(ctx, paramName, metav1.GetOptions{})
+				if err != nil {
+					klog.ErrorS(err, "Failed to get resource")
+					return nil, err
+				}
+				if err = resource.Delete(ctx, paramName, metav1.DeleteOptions{DryRun: dryRun}); err != nil {
+					klog.ErrorS(err, "Failed to delete resource")
+					return nil, err
+				}
+				return dryRunResult(live, nil)
+			}
+
+			if err = resource.Delete(ctx, paramName, metav1.DeleteOptions{}); err != nil {
 				klog.ErrorS(err, "Failed to delete resource")
-				errMsg := ""
 				if paramNamespace != "" {
-					errMsg = fmt.Sprintf("Karmada: failed to delete %s/%s %s-resource", paramNamespace, paramName, paramKind)
-				} else {
-					errMsg = fmt.Sprintf("Karmada: failed to delete %s %s-resource", paramName, paramKind)
+					return nil, fmt.Errorf("failed to delete %s %s/%s: %w", paramKind, paramNamespace, paramName, err)
 				}
-				return mcp.NewToolResultText(errMsg), err
+				return nil, fmt.Errorf("failed to delete %s %s: %w", paramKind, paramName, err)
 			}
+			if !paramDeleteNow {
+				return structuredResult(writeResult[*kubernetesObject]{Message: "delete resource requested"})
+			}
+
+			err = wait.PollUntilContextTimeout(ctx, time.Second, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+				_, getErr := resource.Get(ctx, paramName, metav1.GetOptions{})
+				if errors.IsNotFound(getErr) {
+					return true, nil
+				}
+				return false, getErr
+			})
+			if err != nil {
+				klog.ErrorS(err, "Wait for resource deletion failed")
+				return nil, fmt.Errorf("failed to wait for the deletion of %s %s: %w", paramKind, paramName, err)
+			}
+
+			return structuredResult(writeResult[*kubernetesObject]{Message: "delete resource success"})
+		}
+}
 
-			err = retry.OnError(
-				retry.DefaultRetry,
-				func(err error) bool {
-					return errors.IsNotFound(err)
-				},
-				func() error {
-					_, getErr := verber.Get(paramKind, paramNamespace, paramName)
-					return getErr
-				})
-			if !errors.IsNotFound(err) {
-				klog.ErrorS(err, "Wait for verber delete resource failed")
-				return mcp.NewToolResultText("Wait for verber delete resource failed"), err
+func GetResource(mapper meta.RESTMapper, getDynamicClient GetDynamicClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_resource",
+			mcp.WithDescription("Get any kind of resource in the Karmada control-plane, such as statefulsets, services, configmaps or custom resources, by apiVersion, kind and name"),
+			mcp.WithString("apiVersion", mcp.Required(), mcp.Description("apiVersion of the resource, e.g. apps/v1 or v1")),
+			mcp.WithString("kind", mcp.Required(), mcp.Description("kind of the resource, e.g. StatefulSet")),
+			mcp.WithString("namespace", mcp.Description("namespace for scoped resources, only required for namespace-scoped resources")),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of the resource")),
+			withOutputSchema[kubernetesObject](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			dynamicClient, err := getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+			}
+
+			paramAPIVersion, ok := request.GetArguments()["apiVersion"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter apiVersion not found")
+			}
+			paramKind, ok := request.GetArguments()["kind"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter kind not found")
+			}
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+			paramNamespace, _ := request.GetArguments()["namespace"].(string)
+
+			resource, namespaced, err := resourceClient(mapper, dynamicClient, paramAPIVersion, paramKind)
+			if err != nil {
+				return nil, err
+			}
+			if !namespaced {
+				paramNamespace = ""
+			} else if paramNamespace == "" {
+				return nil, fmt.Errorf("parameter namespace is required for namespace-scoped kind %s", paramKind)
+			}
+			obj, err := resource.Namespace(paramNamespace).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get %s, err: %v", paramKind, err)
+				return nil, err
+			}
+			obj.SetManagedFields(nil)
+			return structuredResult(obj)
+		}
+}
+
+// resourceListResult is the result of list_resources.
+type resourceListResult struct {
+	listPage
+	Items []map[string]interface{} `json:"items"`
+}
+
+func ListResources(mapper meta.RESTMapper, getDynamicClient GetDynamicClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_resources",
+			mcp.WithDescription("List any kind of resources in the Karmada control-plane, such as statefulsets, services, configmaps or custom resources, by apiVersion and kind. The result is paginated, pass nextCursor as cursor to get the next page"),
+			mcp.WithString("apiVersion", mcp.Required(), mcp.Description("apiVersion of the resources, e.g. apps/v1 or v1")),
+			mcp.WithString("kind", mcp.Required(), mcp.Description("kind of the resources, e.g. StatefulSet")),
+			mcp.WithString("namespace", mcp.Description("namespace of namespace-scoped resources, resources in all namespaces are listed if not set")),
+			mcp.WithString("fieldSelector", mcp.Description("only return resources matching the field selector, e.g. metadata.name=nginx")),
+			withListQuery(),
+			withOutputSchema[resourceListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			dynamicClient, err := getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+			}
+
+			paramAPIVersion, ok := request.GetArguments()["apiVersion"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter apiVersion not found")
+			}
+			paramKind, ok := request.GetArguments()["kind"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter kind not found")
+			}
+			paramNamespace, _ := request.GetArguments()["namespace"].(string)
+			paramFieldSelector, _ := request.GetArguments()["fieldSelector"].(string)
+
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+
+			resource, namespaced, err := resourceClient(mapper, dynamicClient, paramAPIVersion, paramKind)
+			if err != nil {
+				return nil, err
+			}
+			if !namespaced {
+				paramNamespace = ""
+			}
+			listOptions := query.listOptions()
+			listOptions.FieldSelector = paramFieldSelector
+			resp, err := resource.Namespace(paramNamespace).List(ctx, listOptions)
+			if err != nil {
+				klog.Errorf("failed to list %s, err: %v", paramKind, err)
+				return nil, err
+			}
+			objects := make([]*unstructured.Unstructured, 0, len(resp.Items))
+			for i := range resp.Items {
+				objects = append(objects, &resp.Items[i])
+			}
+			selected, page := selectObjects(objects, query)
+			items := make([]map[string]interface{}, 0, len(selected))
+			for _, object := range selected {
+				object.SetManagedFields(nil)
+				items = append(items, object.Object)
+			}
+			return structuredResult(resourceListResult{listPage: page, Items: items})
+		}
+}
+
+func ApplyResource(mapper meta.RESTMapper, getDynamicClient GetDynamicClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"apply_resource",
+			mcp.WithDescription("Create or update any kind of resource in the Karmada control-plane with server-side apply, changes are owned by the karmada-mcp-server field manager"),
+			mcp.WithString("content", mcp.Required(), mcp.Description("resource content which in form of yaml, apiVersion, kind and metadata.name are required")),
+			mcp.WithString("namespace", mcp.Description("namespace for scoped resources, defaults to metadata.namespace of the content")),
+			mcp.WithBoolean("force",
+				mcp.DefaultBool(false),
+				mcp.Description("take over the fields owned by other field managers instead of failing with a conflict")),
+			withDryRun(),
+			withOutputSchema[writeResult[*kubernetesObject]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			dynamicClient, err := getDynamicClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+			}
+
+			paramContent, ok := request.GetArguments()["content"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter content not found")
+			}
+			paramNamespace, _ := request.GetArguments()["namespace"].(string)
+			paramForce, _ := request.GetArguments()["force"].(bool)
+
+			obj := &unstructured.Unstructured{}
+			if err = yaml.Unmarshal([]byte(paramContent), &obj.Object); err != nil {
+				klog.Errorf("unmarshal resource error: %v", err)
+				return nil, err
+			}
+			if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+				return nil, fmt.Errorf("content should contain apiVersion, kind and metadata.name")
+			}
+			if paramNamespace == "" {
+				paramNamespace = obj.GetNamespace()
+			}
+
+			resource, namespaced, err := resourceClient(mapper, dynamicClient, obj.GetAPIVersion(), obj.GetKind())
+			if err != nil {
+				return nil, err
+			}
+			if !namespaced {
+				paramNamespace = ""
+			} else if paramNamespace == "" {
+				return nil, fmt.Errorf("parameter namespace is required for namespace-scoped kind %s", obj.GetKind())
+			}
+			obj.SetNamespace(paramNamespace)
+			obj.SetManagedFields(nil)
+			obj.SetResourceVersion("")
+
+			var live runtime.Object
+			dryRun := parseDryRun(request)
+			if dryRun != nil {
+				current, err := resource.Namespace(paramNamespace).Get(ctx, obj.GetName(), metav1.GetOptions{})
+				if err != nil && !errors.IsNotFound(err) {
+					klog.Errorf("get %s error: %v", obj.GetKind(), err)
+					return nil, err
+				}
+				if err == nil {
+					live = current
+				}
+			}
+
+			applyResp, err := resource.Namespace(paramNamespace).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+				DryRun:       dryRun,
+				FieldManager: fieldManager,
+				Force:        paramForce,
+			})
+			if err != nil {
+				klog.Errorf("apply %s error: %v", obj.GetKind(), err)
+				return nil, err
+			}
+			if dryRun != nil {
+				return dryRunResult(live, applyResp)
 			}
+			applyResp.SetManagedFields(nil)
 
-			return mcp.NewToolResultText("delete resource success"), nil
+			return structuredResult(writeResult[*unstructured.Unstructured]{Object: applyResp})
 		}
 }
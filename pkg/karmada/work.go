@@ -0,0 +1,205 @@
+package karmada
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	workv1alpha1 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/karmada-io/karmada/pkg/util/names"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"strings"
+)
+
+// workSummary is the view of a Work returned by the work tools.
+type workSummary struct {
+	Name               string                 `json:"name"`
+	Namespace          string                 `json:"namespace"`
+	Cluster            string                 `json:"cluster"`
+	Binding            *objectReference       `json:"binding,omitempty"`
+	Applied            metav1.ConditionStatus `json:"applied"`
+	AppliedMessage     string                 `json:"appliedMessage,omitempty"`
+	SuspendDispatching bool                   `json:"suspendDispatching,omitempty"`
+	Manifests          []manifestSummary      `json:"manifests"`
+	Conditions         []condition            `json:"conditions,omitempty"`
+}
+
+type manifestSummary struct {
+	APIVersion string                      `json:"apiVersion"`
+	Kind       string                      `json:"kind"`
+	Namespace  string                      `json:"namespace,omitempty"`
+	Name       string                      `json:"name"`
+	Health     workv1alpha1.ResourceHealth `json:"health,omitempty"`
+	Status     json.RawMessage             `json:"status,omitempty"`
+}
+
+func newWorkSummary(work *workv1alpha1.Work, withStatus bool) workSummary {
+	cluster, _ := names.GetClusterName(work.Namespace)
+	summary := workSummary{
+		Name:               work.Name,
+		Namespace:          work.Namespace,
+		Cluster:            cluster,
+		Binding:            workBinding(work.Annotations),
+		Applied:            metav1.ConditionUnknown,
+		SuspendDispatching: work.Spec.SuspendDispatching != nil && *work.Spec.SuspendDispatching,
+		Manifests:          make([]manifestSummary, 0, len(work.Spec.Workload.Manifests)),
+		Conditions:         newConditions(work.Status.Conditions),
+	}
+	if cond := meta.FindStatusCondition(work.Status.Conditions, workv1alpha1.WorkApplied); cond != nil {
+		summary.Applied = cond.Status
+		if cond.Status != metav1.ConditionTrue {
+			summary.AppliedMessage = cond.Message
+		}
+	}
+	for i, manifest := range work.Spec.Workload.Manifests {
+		m := manifestSummary{}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			klog.Warningf("failed to decode manifest %d of work %s/%s, err: %v", i, work.Namespace, work.Name, err)
+		} else {
+			m.APIVersion = obj.GetAPIVersion()
+			m.Kind = obj.GetKind()
+			m.Namespace = obj.GetNamespace()
+			m.Name = obj.GetName()
+		}
+		for _, status := range work.Status.ManifestStatuses {
+			if status.Identifier.Ordinal != i {
+				continue
+			}
+			m.Health = status.Health
+			if withStatus && status.Status != nil {
+				m.Status = status.Status.Raw
+			}
+		}
+		summary.Manifests = append(summary.Manifests, m)
+	}
+	return summary
+}
+
+func workBinding(annotations map[string]string) *objectReference {
+	if name := annotations[workv1alpha2.ResourceBindingNameAnnotationKey]; name != "" {
+		return &objectReference{
+			Kind:      "ResourceBinding",
+			Namespace: annotations[workv1alpha2.ResourceBindingNamespaceAnnotationKey],
+			Name:      name,
+		}
+	}
+	if name := annotations[workv1alpha2.ClusterResourceBindingAnnotationKey]; name != "" {
+		return &objectReference{Kind: "ClusterResourceBinding", Name: name}
+	}
+	return nil
+}
+
+// matchWorkResource reports whether one of the manifests of the work is the resource, empty filters match any.
+func matchWorkResource(summary workSummary, kind, namespace, name string) bool {
+	if kind == "" && namespace == "" && name == "" {
+		return true
+	}
+	for _, m := range summary.Manifests {
+		if (kind == "" || m.Kind == kind) && (namespace == "" || m.Namespace == namespace) && (name == "" || m.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// workListResult is the result of list_work.
+type workListResult struct {
+	listPage
+	Works []workSummary `json:"works"`
+}
+
+func ListWork(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_work",
+			mcp.WithDescription("List works in the execution namespaces (karmada-es-<cluster>) of the Karmada control-plane. A work holds the manifests Karmada will apply to one member cluster, each work is mapped back to the resourcebinding it is rendered from and reports whether it has been applied. The result is paginated, pass nextCursor as cursor to get the next page"),
+			mcp.WithString("cluster", mcp.Description("name of the member cluster, works of all member clusters are listed if not set")),
+			mcp.WithString("resourceKind", mcp.Description("only return the works containing resources of this kind, e.g. Deployment")),
+			mcp.WithString("resourceNamespace", mcp.Description("only return the works containing resources in this namespace")),
+			mcp.WithString("resourceName", mcp.Description("only return the works containing resources with this name")),
+			withListQuery(),
+			withOutputSchema[workListResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramCluster, _ := request.GetArguments()["cluster"].(string)
+			paramKind, _ := request.GetArguments()["resourceKind"].(string)
+			paramResourceNamespace, _ := request.GetArguments()["resourceNamespace"].(string)
+			paramResourceName, _ := request.GetArguments()["resourceName"].(string)
+
+			namespace := metav1.NamespaceAll
+			if paramCluster != "" {
+				namespace = names.GenerateExecutionSpaceName(paramCluster)
+			}
+			query, err := parseListQuery(request)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := karmadaClient.WorkV1alpha1().Works(namespace).List(ctx, query.listOptions())
+			if err != nil {
+				klog.Errorf("failed to list works, err: %v", err)
+				return nil, err
+			}
+			items := make([]*workv1alpha1.Work, 0, len(resp.Items))
+			summaries := make(map[*workv1alpha1.Work]workSummary, len(resp.Items))
+			for i := range resp.Items {
+				if !strings.HasPrefix(resp.Items[i].Namespace, names.ExecutionSpacePrefix) {
+					continue
+				}
+				summary := newWorkSummary(&resp.Items[i], false)
+				if !matchWorkResource(summary, paramKind, paramResourceNamespace, paramResourceName) {
+					continue
+				}
+				items = append(items, &resp.Items[i])
+				summaries[&resp.Items[i]] = summary
+			}
+			selected, page := selectObjects(items, query)
+			works := make([]workSummary, 0, len(selected))
+			for _, work := range selected {
+				works = append(works, summaries[work])
+			}
+			return structuredResult(workListResult{listPage: page, Works: works})
+		}
+}
+
+func GetWork(getKarmadaClient GetKarmadaClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_work",
+			mcp.WithDescription("Get a work in the execution namespace of a member cluster in the Karmada control-plane, including the status of every manifest collected from the member cluster"),
+			mcp.WithString("cluster", mcp.Required(), mcp.Description("name of the member cluster, the work is looked up in the karmada-es-<cluster> namespace")),
+			mcp.WithString("name", mcp.Required(), mcp.Description("name of work")),
+			withOutputSchema[workSummary](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			karmadaClient, err := getKarmadaClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Karmada client: %w", err)
+			}
+
+			paramCluster, ok := request.GetArguments()["cluster"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter cluster not found")
+			}
+
+			paramName, ok := request.GetArguments()["name"].(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name not found")
+			}
+
+			work, err := karmadaClient.WorkV1alpha1().Works(names.GenerateExecutionSpaceName(paramCluster)).Get(ctx, paramName, metav1.GetOptions{})
+			if err != nil {
+				klog.Errorf("failed to get work, err: %v", err)
+				return nil, err
+			}
+			return structuredResult(newWorkSummary(work, true))
+		}
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	clientgometrics "k8s.io/client-go/tools/metrics"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const namespace = "karmada_mcp"
+
+var (
+	// ToolCalls counts the completed tool calls by tool and status, which is success, error or failure like in the audit log
+	ToolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "Number of tool calls by tool and status, status is success, error for tool errors such as calls denied by the tool policy, or failure for calls whose handler failed.",
+	}, []string{"tool", "status"})
+
+	// ToolCallDuration observes the latency of tool calls by tool
+	ToolCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_call_duration_seconds",
+		Help:      "Latency of tool calls by tool.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"tool"})
+
+	// ActiveSessions is the number of connected MCP sessions
+	ActiveSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_sessions",
+		Help:      "Number of connected MCP sessions.",
+	})
+
+	apiserverRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "karmada_apiserver_request_duration_seconds",
+		Help:      "Latency of the requests to the Karmada apiserver by verb and host.",
+		Buckets:   []float64{0.005, 0.025, 0.1, 0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+	}, []string{"verb", "host"})
+
+	apiserverRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "karmada_apiserver_requests_total",
+		Help:      "Number of requests to the Karmada apiserver by status code, method and host.",
+	}, []string{"code", "method", "host"})
+
+	registry = prometheus.NewRegistry()
+)
+
+func init() {
+	registry.MustRegister(
+		ToolCalls,
+		ToolCallDuration,
+		ActiveSessions,
+		apiserverRequestDuration,
+		apiserverRequests,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	// client-go reports the requests of every rest client of the process through these adapters
+	clientgometrics.Register(clientgometrics.RegisterOpts{
+		RequestLatency: latencyAdapter{apiserverRequestDuration},
+		RequestResult:  resultAdapter{apiserverRequests},
+	})
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+type latencyAdapter struct {
+	metric *prometheus.HistogramVec
+}
+
+func (l latencyAdapter) Observe(_ context.Context, verb string, u url.URL, latency time.Duration) {
+	l.metric.WithLabelValues(verb, u.Host).Observe(latency.Seconds())
+}
+
+type resultAdapter struct {
+	metric *prometheus.CounterVec
+}
+
+func (r resultAdapter) Increment(_ context.Context, code, method, host string) {
+	r.metric.WithLabelValues(code, method, host).Inc()
+}
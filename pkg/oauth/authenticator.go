@@ -0,0 +1,237 @@
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/warjiang/karmada-mcp-server/pkg/karmada"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ProtectedResourceMetadataPath is the well-known path of the OAuth 2.0 protected resource metadata, see RFC 9728.
+const ProtectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+// clockSkew is the tolerance applied to the exp and nbf claims.
+const clockSkew = 30 * time.Second
+
+// Authenticator validates the JWT bearer tokens of MCP clients and maps their claims to the
+// user and groups impersonated on the Karmada apiserver.
+type Authenticator struct {
+	options Options
+	keys    *keySet
+}
+
+// NewAuthenticator loads the signing keys of the issuer, from Options.JWKSFile if set, otherwise discovered from the issuer.
+func NewAuthenticator(ctx context.Context, options Options) (*Authenticator, error) {
+	keys, err := newKeySet(ctx, options.IssuerURL, options.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Authenticator{options: options, keys: keys}, nil
+}
+
+// signatureAlgorithms are the JWS algorithms accepted for tokens, go-jose checks the key type, and the curve of EC keys,
+// matches the algorithm of the token.
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// Verify checks the signature, issuer, audience and validity period of the token and returns the
+// credentials the tool calls of its bearer act with.
+func (a *Authenticator) Verify(ctx context.Context, token string) (*karmada.Credentials, error) {
+	signed, err := jose.ParseSignedCompact(token, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %w", err)
+	}
+	header := signed.Signatures[0].Header
+	var payload []byte
+	verified := false
+	for _, key := range a.keys.lookup(ctx, header.KeyID) {
+		if key.alg != "" && key.alg != header.Algorithm {
+			continue
+		}
+		if payload, err = signed.Verify(key.key); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("failed to verify token signature with alg %q and kid %q", header.Algorithm, header.KeyID)
+	}
+
+	claims := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+	return a.credentials(claims, time.Now())
+}
+
+func (a *Authenticator) credentials(claims map[string]interface{}, now time.Time) (*karmada.Credentials, error) {
+	if iss, _ := claims["iss"].(string); iss != a.options.IssuerURL {
+		return nil, fmt.Errorf("token issued by %q, expected %q", iss, a.options.IssuerURL)
+	}
+	audiences, ok := stringsClaim(claims["aud"])
+	if !ok || !contains(audiences, a.options.Audience) {
+		return nil, fmt.Errorf("token not issued for audience %q", a.options.Audience)
+	}
+	exp, ok := timeClaim(claims["exp"])
+	if !ok {
+		return nil, fmt.Errorf("token has no exp claim")
+	}
+	if now.After(exp.Add(clockSkew)) {
+		return nil, fmt.Errorf("token expired at %s", exp.Format(time.RFC3339))
+	}
+	if nbf, ok := timeClaim(claims["nbf"]); ok && now.Add(clockSkew).Before(nbf) {
+		return nil, fmt.Errorf("token not valid before %s", nbf.Format(time.RFC3339))
+	}
+
+	username, _ := claims[a.options.UsernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("token has no %s claim", a.options.UsernameClaim)
+	}
+	// Like the Kubernetes OIDC authenticator, only trust an email the issuer has verified
+	if a.options.UsernameClaim == "email" {
+		if verified, ok := claims["email_verified"]; ok && verified != true {
+			return nil, fmt.Errorf("email %q of token is not verified", username)
+		}
+	}
+	impersonate := rest.ImpersonationConfig{UserName: a.options.UsernamePrefix + username}
+	if a.options.GroupsClaim != "" {
+		groups, _ := stringsClaim(claims[a.options.GroupsClaim])
+		for _, group := range groups {
+			impersonate.Groups = append(impersonate.Groups, a.options.GroupsPrefix+group)
+		}
+	}
+	return &karmada.Credentials{Impersonate: impersonate}, nil
+}
+
+// Handler rejects requests without a valid bearer token and serves the protected resource metadata,
+// endpointPath is the path of the MCP endpoint the resource URL is derived from.
+// The credentials of authorized requests are stored in the request context, the Authorization and
+// Impersonate-* headers are removed so that they are not forwarded to the Karmada apiserver.
+func (a *Authenticator) Handler(next http.Handler, endpointPath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == ProtectedResourceMetadataPath || strings.HasPrefix(r.URL.Path, ProtectedResourceMetadataPath+"/") {
+			a.serveMetadata(w, r, endpointPath)
+			return
+		}
+
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			a.unauthorized(w, r, endpointPath, "")
+			return
+		}
+		credentials, err := a.Verify(r.Context(), strings.TrimSpace(token))
+		if err != nil {
+			klog.V(2).Infof("rejected request from %s, err: %v", r.RemoteAddr, err)
+			a.unauthorized(w, r, endpointPath, "invalid_token")
+			return
+		}
+		klog.V(4).Infof("authorized request from %s as %s", r.RemoteAddr, credentials.Impersonate.UserName)
+
+		r = r.Clone(karmada.WithCredentials(r.Context(), credentials))
+		r.Header.Del("Authorization")
+		for name := range r.Header {
+			if strings.HasPrefix(name, "Impersonate-") {
+				r.Header.Del(name)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *Authenticator) resourceURL(r *http.Request, endpointPath string) string {
+	if a.options.ResourceURL != "" {
+		return a.options.ResourceURL
+	}
+	return baseURL(r) + endpointPath
+}
+
+func (a *Authenticator) serveMetadata(w http.ResponseWriter, r *http.Request, endpointPath string) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	metadata := map[string]interface{}{
+		"resource":                 a.resourceURL(r, endpointPath),
+		"authorization_servers":    []string{a.options.IssuerURL},
+		"bearer_methods_supported": []string{"header"},
+		"resource_name":            "karmada-mcp-server",
+	}
+	if len(a.options.Scopes) > 0 {
+		metadata["scopes_supported"] = a.options.Scopes
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(metadata)
+}
+
+// unauthorized points the client to the protected resource metadata, which names the
+// authorization server to obtain a token from, see the authorization section of the MCP spec.
+func (a *Authenticator) unauthorized(w http.ResponseWriter, r *http.Request, endpointPath, errorCode string) {
+	challenge := fmt.Sprintf(`Bearer resource_metadata="%s%s%s"`, baseURL(r), ProtectedResourceMetadataPath, endpointPath)
+	if errorCode != "" {
+		challenge += fmt.Sprintf(`, error="%s"`, errorCode)
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// stringsClaim accepts a claim holding a single string or an array of strings, like aud and groups.
+func stringsClaim(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+func timeClaim(value interface{}) (time.Time, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,406 @@
+package oauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/warjiang/karmada-mcp-server/pkg/karmada"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "karmada-mcp-server"
+)
+
+// testKeys are the signing keys of the test issuer, published in the JWKS file of newTestAuthenticator.
+type testKeys struct {
+	rsa   *rsa.PrivateKey
+	ec    *ecdsa.PrivateKey
+	ec384 *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	return testKeys{rsa: rsaKey, ec: ecKey, ec384: ec384Key}
+}
+
+func newTestAuthenticator(t *testing.T, keys testKeys, options Options) *Authenticator {
+	t.Helper()
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &keys.rsa.PublicKey, KeyID: "rsa", Use: "sig", Algorithm: "RS256"},
+		{Key: &keys.ec.PublicKey, KeyID: "ec", Use: "sig", Algorithm: "ES256"},
+		// the key does not restrict the algorithm, only the ones of its curve are accepted
+		{Key: &keys.ec384.PublicKey, KeyID: "ec384", Use: "sig"},
+	}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwksFile, data, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+
+	options.IssuerURL = testIssuer
+	options.JWKSFile = jwksFile
+	options.Audience = testAudience
+	if options.UsernameClaim == "" {
+		options.UsernameClaim = "sub"
+	}
+	if options.GroupsClaim == "" {
+		options.GroupsClaim = "groups"
+	}
+	authenticator, err := NewAuthenticator(context.Background(), options)
+	if err != nil {
+		t.Fatalf("failed to create authenticator: %v", err)
+	}
+	return authenticator
+}
+
+// validClaims returns claims accepted by the authenticators of newTestAuthenticator.
+func validClaims() map[string]interface{} {
+	now := time.Now()
+	return map[string]interface{}{
+		"iss":    testIssuer,
+		"aud":    []string{testAudience},
+		"sub":    "alice",
+		"groups": []string{"dev", "ops"},
+		"iat":    now.Unix(),
+		"nbf":    now.Unix(),
+		"exp":    now.Add(time.Hour).Unix(),
+	}
+}
+
+// signToken signs the claims with the key, which is an RSA or EC private key, or the HMAC secret for HS256.
+func signToken(t *testing.T, alg, kid string, key interface{}, claims map[string]interface{}) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatalf("failed to marshal header: %v", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch alg {
+	case "none":
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	case "ES256":
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, key.(*ecdsa.PrivateKey), digest[:])
+		if err == nil {
+			signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	case "HS256":
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	default:
+		t.Fatalf("unsupported alg %s", alg)
+	}
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	keys := newTestKeys(t)
+	authenticator := newTestAuthenticator(t, keys, Options{UsernamePrefix: "oidc:", GroupsPrefix: "oidc:"})
+	expected := &karmada.Credentials{}
+	expected.Impersonate.UserName = "oidc:alice"
+	expected.Impersonate.Groups = []string{"oidc:dev", "oidc:ops"}
+
+	for _, alg := range []string{"RS256", "ES256"} {
+		t.Run(alg, func(t *testing.T) {
+			key, kid := interface{}(keys.rsa), "rsa"
+			if alg == "ES256" {
+				key, kid = keys.ec, "ec"
+			}
+			credentials, err := authenticator.Verify(context.Background(), signToken(t, alg, kid, key, validClaims()))
+			if err != nil {
+				t.Fatalf("expected token to be valid, got %v", err)
+			}
+			if !reflect.DeepEqual(credentials, expected) {
+				t.Errorf("expected credentials %+v, got %+v", expected, credentials)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	keys := newTestKeys(t)
+	authenticator := newTestAuthenticator(t, keys, Options{})
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		claims[name] = value
+		return claims
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "wrong issuer",
+			token: signToken(t, "RS256", "rsa", keys.rsa, withClaim("iss", "https://other.example.com")),
+		},
+		{
+			name:  "wrong audience",
+			token: signToken(t, "RS256", "rsa", keys.rsa, withClaim("aud", "other")),
+		},
+		{
+			name:  "expired",
+			token: signToken(t, "RS256", "rsa", keys.rsa, withClaim("exp", time.Now().Add(-time.Hour).Unix())),
+		},
+		{
+			name:  "not valid yet",
+			token: signToken(t, "RS256", "rsa", keys.rsa, withClaim("nbf", time.Now().Add(time.Hour).Unix())),
+		},
+		{
+			name:  "alg none",
+			token: signToken(t, "none", "rsa", nil, validClaims()),
+		},
+		{
+			// the public key must not be usable as an HMAC secret
+			name:  "alg HS256",
+			token: signToken(t, "HS256", "rsa", keys.rsa.N.Bytes(), validClaims()),
+		},
+		{
+			name:  "unknown kid",
+			token: signToken(t, "RS256", "unknown", keys.rsa, validClaims()),
+		},
+		{
+			name:  "key of another alg",
+			token: signToken(t, "RS256", "ec", keys.rsa, validClaims()),
+		},
+		{
+			name:  "EC key of another curve",
+			token: signToken(t, "ES256", "ec384", keys.ec, validClaims()),
+		},
+		{
+			name: "tampered claims",
+			token: func() string {
+				parts := strings.Split(signToken(t, "RS256", "rsa", keys.rsa, validClaims()), ".")
+				claims, _ := json.Marshal(withClaim("sub", "admin"))
+				parts[1] = base64.RawURLEncoding.EncodeToString(claims)
+				return strings.Join(parts, ".")
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if credentials, err := authenticator.Verify(context.Background(), tt.token); err == nil {
+				t.Errorf("expected token to be rejected, got credentials %+v", credentials)
+			}
+		})
+	}
+}
+
+func TestParseVerificationKey(t *testing.T) {
+	keys := newTestKeys(t)
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	marshal := func(jwk jose.JSONWebKey, keyOps ...string) json.RawMessage {
+		data, err := jwk.MarshalJSON()
+		if err != nil {
+			t.Fatalf("failed to marshal JWK: %v", err)
+		}
+		if keyOps == nil {
+			return data
+		}
+		fields := map[string]interface{}{}
+		_ = json.Unmarshal(data, &fields)
+		fields["key_ops"] = keyOps
+		data, _ = json.Marshal(fields)
+		return data
+	}
+
+	tests := []struct {
+		name  string
+		jwk   json.RawMessage
+		valid bool
+	}{
+		{name: "RSA signing key", jwk: marshal(jose.JSONWebKey{Key: &keys.rsa.PublicKey, KeyID: "rsa", Use: "sig"}), valid: true},
+		{name: "EC key with verify key_ops", jwk: marshal(jose.JSONWebKey{Key: &keys.ec.PublicKey, KeyID: "ec"}, "verify"), valid: true},
+		{name: "RSA key shorter than 2048 bits", jwk: marshal(jose.JSONWebKey{Key: &smallRSA.PublicKey, KeyID: "small"})},
+		{name: "encryption key", jwk: marshal(jose.JSONWebKey{Key: &keys.rsa.PublicKey, KeyID: "enc", Use: "enc"})},
+		{name: "key_ops without verify", jwk: marshal(jose.JSONWebKey{Key: &keys.ec.PublicKey, KeyID: "ops"}, "encrypt")},
+		{name: "symmetric key", jwk: marshal(jose.JSONWebKey{Key: []byte("secret-secret-secret"), KeyID: "oct"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseVerificationKey(tt.jwk)
+			if tt.valid && err != nil {
+				t.Errorf("expected key to be accepted, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("expected key to be rejected")
+			}
+		})
+	}
+}
+
+func TestVerifyEmailClaim(t *testing.T) {
+	keys := newTestKeys(t)
+	authenticator := newTestAuthenticator(t, keys, Options{UsernameClaim: "email"})
+
+	tests := []struct {
+		name     string
+		verified interface{}
+		valid    bool
+	}{
+		{name: "verified", verified: true, valid: true},
+		{name: "unverified", verified: false, valid: false},
+		{name: "not a boolean", verified: "true", valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			claims["email"] = "alice@example.com"
+			claims["email_verified"] = tt.verified
+			credentials, err := authenticator.Verify(context.Background(), signToken(t, "ES256", "ec", keys.ec, claims))
+			if !tt.valid {
+				if err == nil {
+					t.Errorf("expected token to be rejected, got credentials %+v", credentials)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected token to be valid, got %v", err)
+			}
+			if credentials.Impersonate.UserName != "alice@example.com" {
+				t.Errorf("expected user alice@example.com, got %s", credentials.Impersonate.UserName)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	keys := newTestKeys(t)
+	authenticator := newTestAuthenticator(t, keys, Options{})
+	var forwarded *http.Request
+	handler := authenticator.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r
+	}), "/mcp")
+	resourceMetadata := `Bearer resource_metadata="http://karmada-mcp.example.com/.well-known/oauth-protected-resource/mcp"`
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		challenge     string
+	}{
+		{
+			name:      "no token",
+			status:    http.StatusUnauthorized,
+			challenge: resourceMetadata,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer " + signToken(t, "RS256", "rsa", keys.rsa, map[string]interface{}{"iss": testIssuer}),
+			status:        http.StatusUnauthorized,
+			challenge:     resourceMetadata + `, error="invalid_token"`,
+		},
+		{
+			name:          "valid token",
+			authorization: "Bearer " + signToken(t, "RS256", "rsa", keys.rsa, validClaims()),
+			status:        http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forwarded = nil
+			r := httptest.NewRequest(http.MethodPost, "http://karmada-mcp.example.com/mcp", nil)
+			r.Header.Set("Impersonate-User", "admin")
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, w.Code)
+			}
+			if challenge := w.Header().Get("WWW-Authenticate"); challenge != tt.challenge {
+				t.Errorf("expected WWW-Authenticate %q, got %q", tt.challenge, challenge)
+			}
+			if tt.status != http.StatusOK {
+				if forwarded != nil {
+					t.Errorf("expected request not to be forwarded")
+				}
+				return
+			}
+			if forwarded == nil {
+				t.Fatalf("expected request to be forwarded")
+			}
+			if forwarded.Header.Get("Authorization") != "" || forwarded.Header.Get("Impersonate-User") != "" {
+				t.Errorf("expected Authorization and Impersonate-* headers to be removed, got %v", forwarded.Header)
+			}
+			credentials := karmada.CredentialsFromContext(forwarded.Context())
+			if credentials == nil || credentials.Impersonate.UserName != "alice" {
+				t.Errorf("expected credentials of alice in the request context, got %+v", credentials)
+			}
+		})
+	}
+}
+
+func TestHandlerServesProtectedResourceMetadata(t *testing.T) {
+	authenticator := newTestAuthenticator(t, newTestKeys(t), Options{})
+	handler := authenticator.Handler(http.NotFoundHandler(), "/mcp")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://karmada-mcp.example.com"+ProtectedResourceMetadataPath+"/mcp", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var metadata struct {
+		Resource             string   `json:"resource"`
+		AuthorizationServers []string `json:"authorization_servers"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &metadata); err != nil {
+		t.Fatalf("failed to decode metadata: %v", err)
+	}
+	if metadata.Resource != "http://karmada-mcp.example.com/mcp" {
+		t.Errorf("expected resource http://karmada-mcp.example.com/mcp, got %s", metadata.Resource)
+	}
+	if !reflect.DeepEqual(metadata.AuthorizationServers, []string{testIssuer}) {
+		t.Errorf("expected authorization servers [%s], got %v", testIssuer, metadata.AuthorizationServers)
+	}
+}
@@ -0,0 +1,201 @@
+package oauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"github.com/go-jose/go-jose/v4"
+	"io"
+	"k8s.io/klog/v2"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval bounds how often the key set is reloaded when a token is signed by an unknown key.
+const minRefreshInterval = time.Minute
+
+// minRSAKeySize is the minimum modulus size in bits of the RSA keys tokens are verified with.
+const minRSAKeySize = 2048
+
+// verificationKey is a public key of the key set, alg is empty if the key does not restrict the algorithm.
+type verificationKey struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+// keySet holds the keys tokens are verified against, read from a file or the jwks_uri of the issuer.
+type keySet struct {
+	issuerURL string
+	jwksFile  string
+	client    *http.Client
+
+	mu          sync.RWMutex
+	keys        []verificationKey
+	lastRefresh time.Time
+}
+
+func newKeySet(ctx context.Context, issuerURL, jwksFile string) (*keySet, error) {
+	s := &keySet{
+		issuerURL: issuerURL,
+		jwksFile:  jwksFile,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// lookup returns the keys matching the kid of a token, the key set is reloaded once if none matches,
+// so that keys rotated at the issuer are picked up.
+func (s *keySet) lookup(ctx context.Context, kid string) []verificationKey {
+	if keys := s.match(kid); len(keys) > 0 {
+		return keys
+	}
+	s.mu.RLock()
+	stale := time.Since(s.lastRefresh) >= minRefreshInterval
+	s.mu.RUnlock()
+	if !stale {
+		return nil
+	}
+	if err := s.refresh(ctx); err != nil {
+		klog.Errorf("failed to refresh JSON Web Key Set, err: %v", err)
+		return nil
+	}
+	return s.match(kid)
+}
+
+func (s *keySet) match(kid string) []verificationKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var keys []verificationKey
+	for _, key := range s.keys {
+		if kid == "" || key.kid == "" || key.kid == kid {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (s *keySet) refresh(ctx context.Context) error {
+	var data []byte
+	var err error
+	if s.jwksFile != "" {
+		data, err = os.ReadFile(s.jwksFile)
+		if err != nil {
+			return fmt.Errorf("failed to read JSON Web Key Set file %s: %w", s.jwksFile, err)
+		}
+	} else {
+		data, err = s.fetchFromIssuer(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to decode JSON Web Key Set: %w", err)
+	}
+	keys := make([]verificationKey, 0, len(set.Keys))
+	for _, raw := range set.Keys {
+		key, err := parseVerificationKey(raw)
+		if err != nil {
+			klog.Warningf("skipping JSON Web Key, err: %v", err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no usable signing key in JSON Web Key Set")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+	s.lastRefresh = time.Now()
+	return nil
+}
+
+// fetchFromIssuer discovers the jwks_uri from the OpenID provider configuration of the issuer and downloads the key set.
+func (s *keySet) fetchFromIssuer(ctx context.Context) ([]byte, error) {
+	discoveryURL := strings.TrimSuffix(s.issuerURL, "/") + "/.well-known/openid-configuration"
+	data, err := s.get(ctx, discoveryURL)
+	if err != nil {
+		return nil, err
+	}
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.Unmarshal(data, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", discoveryURL, err)
+	}
+	if discovery.Issuer != s.issuerURL {
+		return nil, fmt.Errorf("issuer %q of %s does not match %q", discovery.Issuer, discoveryURL, s.issuerURL)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("no jwks_uri in %s", discoveryURL)
+	}
+	return s.get(ctx, discovery.JWKSURI)
+}
+
+func (s *keySet) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// parseVerificationKey parses a JSON Web Key with go-jose, which rejects EC points off their curve, and only
+// accepts RSA keys of at least minRSAKeySize bits, EC and Ed25519 keys usable for verifying signatures.
+func parseVerificationKey(raw json.RawMessage) (verificationKey, error) {
+	var jwk jose.JSONWebKey
+	if err := jwk.UnmarshalJSON(raw); err != nil {
+		return verificationKey{}, err
+	}
+	// go-jose does not parse key_ops
+	var usage struct {
+		KeyOps []string `json:"key_ops"`
+	}
+	if err := json.Unmarshal(raw, &usage); err != nil {
+		return verificationKey{}, err
+	}
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return verificationKey{}, fmt.Errorf("key %q is not a signing key, use is %q", jwk.KeyID, jwk.Use)
+	}
+	if usage.KeyOps != nil && !slices.Contains(usage.KeyOps, "verify") {
+		return verificationKey{}, fmt.Errorf("key %q can not verify signatures, key_ops are %v", jwk.KeyID, usage.KeyOps)
+	}
+
+	public := jwk.Public()
+	switch key := public.Key.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeySize {
+			return verificationKey{}, fmt.Errorf("RSA key %q has %d bits, at least %d are required", jwk.KeyID, key.N.BitLen(), minRSAKeySize)
+		}
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %T of key %q", jwk.Key, jwk.KeyID)
+	}
+	return verificationKey{kid: jwk.KeyID, alg: jwk.Algorithm, key: public.Key}, nil
+}
@@ -0,0 +1,77 @@
+package oauth
+
+import (
+	"fmt"
+	"github.com/spf13/pflag"
+)
+
+// Options configures the OAuth 2.1 / OIDC authorization of the http transports.
+type Options struct {
+	// IssuerURL is the URL of the OIDC issuer, tokens must carry it in the iss claim, authorization is disabled if empty
+	IssuerURL string
+
+	// JWKSFile is a file containing the JSON Web Key Set tokens are verified against,
+	// the keys are discovered from the issuer if empty
+	JWKSFile string
+
+	// Audience is the value tokens must carry in the aud claim
+	Audience string
+
+	// UsernameClaim is the claim mapped to the impersonated Kubernetes user
+	UsernameClaim string
+
+	// UsernamePrefix is prepended to the username
+	UsernamePrefix string
+
+	// GroupsClaim is the claim mapped to the impersonated Kubernetes groups
+	GroupsClaim string
+
+	// GroupsPrefix is prepended to every group
+	GroupsPrefix string
+
+	// ResourceURL is the URL of the MCP server announced in the protected resource metadata,
+	// defaults to the scheme and host of the request followed by the MCP endpoint path
+	ResourceURL string
+
+	// Scopes are the scopes announced in the protected resource metadata
+	Scopes []string
+}
+
+// AddFlags adds flags of api to the specified FlagSet
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	if o == nil {
+		return
+	}
+
+	fs.StringVar(&o.IssuerURL, "oidc-issuer-url", "", "The URL of the OIDC issuer, enables OAuth 2.1 authorization of MCP clients, every request must carry a JWT bearer token issued by it")
+	fs.StringVar(&o.JWKSFile, "oidc-jwks-file", "", "File containing the JSON Web Key Set tokens are verified against, the keys are discovered from --oidc-issuer-url if not set")
+	fs.StringVar(&o.Audience, "oidc-audience", "", "The audience tokens must be issued for, e.g. the URL of this server or the client ID registered at the issuer")
+	fs.StringVar(&o.UsernameClaim, "oidc-username-claim", "sub", "The claim mapped to the user impersonated on the Karmada apiserver")
+	fs.StringVar(&o.UsernamePrefix, "oidc-username-prefix", "", "The prefix prepended to the username, e.g. oidc:")
+	fs.StringVar(&o.GroupsClaim, "oidc-groups-claim", "groups", "The claim mapped to the groups impersonated on the Karmada apiserver")
+	fs.StringVar(&o.GroupsPrefix, "oidc-groups-prefix", "", "The prefix prepended to every group, e.g. oidc:")
+	fs.StringVar(&o.ResourceURL, "oidc-resource-url", "", "The URL of this MCP server announced in the protected resource metadata, derived from the request if not set")
+	fs.StringSliceVar(&o.Scopes, "oidc-scopes", nil, "The scopes announced in the protected resource metadata")
+}
+
+// Enabled reports whether authorization is configured.
+func (o *Options) Enabled() bool {
+	return o != nil && o.IssuerURL != ""
+}
+
+// Validate checks the flags are consistent.
+func (o *Options) Validate() error {
+	if !o.Enabled() {
+		if o != nil && o.JWKSFile != "" {
+			return fmt.Errorf("--oidc-jwks-file requires --oidc-issuer-url")
+		}
+		return nil
+	}
+	if o.Audience == "" {
+		return fmt.Errorf("--oidc-audience is required with --oidc-issuer-url")
+	}
+	if o.UsernameClaim == "" {
+		return fmt.Errorf("--oidc-username-claim must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package toolpolicy
+
+import (
+	"context"
+	"fmt"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog/v2"
+	"os"
+	"path"
+	"sigs.k8s.io/yaml"
+)
+
+// Action is the decision of a rule.
+type Action string
+
+const (
+	ActionAllow Action = "allow"
+	ActionDeny  Action = "deny"
+)
+
+// Policy decides which tool calls are allowed. Rules are evaluated in order and the first rule
+// matching the tool decides, calls of tools no rule matches get DefaultAction.
+//
+// An example policy, which only allows deleting resources in team namespaces and caps the replicas of deployments:
+//
+//	defaultAction: allow
+//	rules:
+//	- tools: ["delete_unstructured_resource"]
+//	  namespaces: ["team-*"]
+//	- tools: ["unjoin_cluster", "delete_*policy"]
+//	  action: deny
+//	  reason: clusters and policies are managed by the platform team
+//	- tools: ["create_deployment", "apply_resource"]
+//	  kinds: ["Deployment", "ConfigMap"]
+//	  arguments:
+//	    content:
+//	      maxLength: 65536
+type Policy struct {
+	// DefaultAction applies to the calls of tools no rule matches, defaults to allow
+	DefaultAction Action `json:"defaultAction,omitempty"`
+
+	Rules []Rule `json:"rules"`
+}
+
+// Rule allows or denies the calls of the tools it matches. The calls an allow rule matches are
+// denied if they violate one of its restrictions.
+type Rule struct {
+	// Tools are the glob patterns of the tool names the rule matches, e.g. delete_*
+	Tools []string `json:"tools"`
+
+	// Action of the rule, defaults to allow
+	Action Action `json:"action,omitempty"`
+
+	// Namespaces are the glob patterns of the namespaces the tools may target, taken from the namespace
+	// argument or the metadata.namespace of the content argument, calls without a namespace are denied
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Kinds are the glob patterns of the kinds the tools may target, taken from the kind of the content
+	// argument or the kind argument, calls without a kind are denied
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Arguments caps the values of the arguments by name
+	Arguments map[string]ArgumentConstraint `json:"arguments,omitempty"`
+
+	// Reason is returned to the MCP client when the rule denies a call
+	Reason string `json:"reason,omitempty"`
+}
+
+// ArgumentConstraint caps the value of an argument, a constraint on an argument missing from the call is satisfied.
+type ArgumentConstraint struct {
+	// Min is the minimum of a number argument
+	Min *float64 `json:"min,omitempty"`
+
+	// Max is the maximum of a number argument
+	Max *float64 `json:"max,omitempty"`
+
+	// MaxLength is the maximum length of a string argument
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Values are the values the argument may take
+	Values []interface{} `json:"values,omitempty"`
+}
+
+// Load reads and validates the policy file.
+func Load(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool policy file %s: %w", file, err)
+	}
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to decode tool policy file %s: %w", file, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid tool policy file %s: %w", file, err)
+	}
+	return policy, nil
+}
+
+func (p *Policy) validate() error {
+	if p.DefaultAction == "" {
+		p.DefaultAction = ActionAllow
+	}
+	if p.DefaultAction != ActionAllow && p.DefaultAction != ActionDeny {
+		return fmt.Errorf("defaultAction must be %s or %s", ActionAllow, ActionDeny)
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Action == "" {
+			rule.Action = ActionAllow
+		}
+		if rule.Action != ActionAllow && rule.Action != ActionDeny {
+			return fmt.Errorf("rules[%d].action must be %s or %s", i, ActionAllow, ActionDeny)
+		}
+		if len(rule.Tools) == 0 {
+			return fmt.Errorf("rules[%d].tools must not be empty", i)
+		}
+		for _, patterns := range [][]string{rule.Tools, rule.Namespaces, rule.Kinds} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("rules[%d] has invalid pattern %q: %w", i, pattern, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate returns an error describing why the call of the tool is denied, nil if it is allowed.
+func (p *Policy) Evaluate(tool mcp.Tool, request mcp.CallToolRequest) error {
+	name := request.Params.Name
+	for i, rule := range p.Rules {
+		if !matchAny(rule.Tools, name) {
+			continue
+		}
+		if rule.Action == ActionDeny {
+			return rule.denied(fmt.Sprintf("tool %s is denied by rule %d of the tool policy", name, i))
+		}
+		if err := rule.check(tool, request.GetArguments()); err != nil {
+			return rule.denied(fmt.Sprintf("tool %s is denied by rule %d of the tool policy: %v", name, i, err))
+		}
+		return nil
+	}
+	if p.DefaultAction == ActionDeny {
+		return fmt.Errorf("tool %s is not allowed by the tool policy", name)
+	}
+	return nil
+}
+
+func (r *Rule) denied(message string) error {
+	if r.Reason != "" {
+		return fmt.Errorf("%s, %s", message, r.Reason)
+	}
+	return fmt.Errorf("%s", message)
+}
+
+func (r *Rule) check(tool mcp.Tool, arguments map[string]interface{}) error {
+	target, err := TargetOf(tool, arguments)
+	if err != nil {
+		return err
+	}
+	namespace, kind := target.Namespace, target.Kind
+	if len(r.Namespaces) > 0 && !matchAny(r.Namespaces, namespace) {
+		if namespace == "" {
+			return fmt.Errorf("a namespace matching %v must be set", r.Namespaces)
+		}
+		return fmt.Errorf("namespace %s does not match %v", namespace, r.Namespaces)
+	}
+	if len(r.Kinds) > 0 && !matchAny(r.Kinds, kind) {
+		if kind == "" {
+			return fmt.Errorf("a kind matching %v must be set", r.Kinds)
+		}
+		return fmt.Errorf("kind %s does not match %v", kind, r.Kinds)
+	}
+	for name, constraint := range r.Arguments {
+		value, ok := arguments[name]
+		if !ok {
+			continue
+		}
+		if err := constraint.check(value); err != nil {
+			return fmt.Errorf("argument %s %v", name, err)
+		}
+	}
+	return nil
+}
+
+func (c ArgumentConstraint) check(value interface{}) error {
+	if c.Min != nil || c.Max != nil {
+		number, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("must be a number")
+		}
+		if c.Min != nil && number < *c.Min {
+			return fmt.Errorf("must be at least %v", *c.Min)
+		}
+		if c.Max != nil && number > *c.Max {
+			return fmt.Errorf("must be at most %v", *c.Max)
+		}
+	}
+	if c.MaxLength != nil {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		if len(s) > *c.MaxLength {
+			return fmt.Errorf("must be at most %d characters long", *c.MaxLength)
+		}
+	}
+	if len(c.Values) > 0 {
+		for _, allowed := range c.Values {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %v", c.Values)
+	}
+	return nil
+}
+
+// Target is the object a tool call operates on, as far as it can be told from the arguments.
+type Target struct {
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// TargetOf returns the target of a call of the tool, only the arguments the tool declares are taken into account,
+// since mcp-go does not validate the arguments against the input schema. The kind of tools taking a content
+// manifest is always the kind of the manifest, and a kind, namespace or name argument contradicting the manifest
+// is an error, so that a call can not claim another target than the object the tool acts on.
+// The target resolved so far is returned with the error.
+func TargetOf(tool mcp.Tool, arguments map[string]interface{}) (Target, error) {
+	argument := func(name string) string {
+		if _, ok := tool.InputSchema.Properties[name]; !ok {
+			return ""
+		}
+		value, _ := arguments[name].(string)
+		return value
+	}
+	target := Target{
+		Cluster:   argument("cluster"),
+		Namespace: argument("namespace"),
+		Kind:      argument("kind"),
+		Name:      argument("name"),
+	}
+	content := argument("content")
+	if content == "" {
+		return target, nil
+	}
+	manifest := struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+		} `json:"metadata"`
+	}{}
+	if err := yaml.Unmarshal([]byte(content), &manifest); err != nil {
+		return target, fmt.Errorf("failed to decode content: %w", err)
+	}
+	for _, field := range []struct {
+		argument string
+		value    *string
+		manifest string
+	}{
+		{"kind", &target.Kind, manifest.Kind},
+		{"namespace", &target.Namespace, manifest.Metadata.Namespace},
+		{"name", &target.Name, manifest.Metadata.Name},
+	} {
+		if *field.value != "" && field.manifest != "" && *field.value != field.manifest {
+			return target, fmt.Errorf("argument %s %q does not match %q of the content", field.argument, *field.value, field.manifest)
+		}
+		if *field.value == "" {
+			*field.value = field.manifest
+		}
+	}
+	return target, nil
+}
+
+// CalledTool returns the definition of the tool called with the name and whether the tool exists, the tools of
+// the session take precedence over the tools of the server the same way mcp-go resolves them. A tool without
+// arguments is returned if it is not found.
+func CalledTool(ctx context.Context, name string) (mcp.Tool, bool) {
+	if session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithTools); ok {
+		if tool, ok := session.GetSessionTools()[name]; ok {
+			return tool.Tool, true
+		}
+	}
+	if s := server.ServerFromContext(ctx); s != nil {
+		if tool := s.GetTool(name); tool != nil {
+			return tool.Tool, true
+		}
+	}
+	return mcp.Tool{Name: name}, false
+}
+
+func matchAny(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware rejects the calls denied by the policy with a tool error, so the model sees why the call failed.
+// It is a tool handler middleware rather than a BeforeCallTool hook, because hooks can not abort a call.
+func (p *Policy) Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tool, _ := CalledTool(ctx, request.Params.Name)
+		if err := p.Evaluate(tool, request); err != nil {
+			klog.Infof("denied call of tool %s, err: %v", request.Params.Name, err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return next(ctx, request)
+	}
+}
+
+// ResourceMiddleware applies the policy to resources/read, toolCall returns the tool reading the same object as
+// the resource and its call, so that the resources can not be used to read what the tools are denied.
+func (p *Policy) ResourceMiddleware(toolCall func(uri string) (mcp.Tool, mcp.CallToolRequest)) server.ResourceHandlerMiddleware {
+	return func(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			if err := p.Evaluate(toolCall(request.Params.URI)); err != nil {
+				klog.Infof("denied read of resource %s, err: %v", request.Params.URI, err)
+				return nil, err
+			}
+			return next(ctx, request)
+		}
+	}
+}
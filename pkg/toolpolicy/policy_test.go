@@ -0,0 +1,124 @@
+package toolpolicy
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"testing"
+)
+
+const clusterRoleBinding = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: admin
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+`
+
+const configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: team-a
+`
+
+func TestEvaluateResolvesTargetFromDeclaredArguments(t *testing.T) {
+	policy := &Policy{
+		DefaultAction: ActionDeny,
+		Rules: []Rule{
+			{Tools: []string{"apply_resource"}, Kinds: []string{"ConfigMap"}},
+			{Tools: []string{"create_deployment"}, Namespaces: []string{"team-*"}},
+		},
+	}
+	if err := policy.validate(); err != nil {
+		t.Fatalf("invalid policy: %v", err)
+	}
+	// apply_resource takes the kind and name from the content, create_deployment declares its namespace and name
+	applyResource := mcp.NewTool("apply_resource",
+		mcp.WithString("content", mcp.Required()),
+		mcp.WithString("namespace"),
+	)
+	createDeployment := mcp.NewTool("create_deployment",
+		mcp.WithString("name", mcp.Required()),
+		mcp.WithString("namespace", mcp.Required()),
+		mcp.WithString("content", mcp.Required()),
+	)
+
+	tests := []struct {
+		name      string
+		tool      mcp.Tool
+		arguments map[string]interface{}
+		allowed   bool
+	}{
+		{
+			name:      "kind of the content",
+			tool:      applyResource,
+			arguments: map[string]interface{}{"content": configMap},
+			allowed:   true,
+		},
+		{
+			name:      "undeclared kind argument spoofing the kind of the content",
+			tool:      applyResource,
+			arguments: map[string]interface{}{"content": clusterRoleBinding, "kind": "ConfigMap"},
+			allowed:   false,
+		},
+		{
+			name:      "undeclared kind argument without content",
+			tool:      applyResource,
+			arguments: map[string]interface{}{"kind": "ConfigMap"},
+			allowed:   false,
+		},
+		{
+			name:      "namespace argument matching the content",
+			tool:      applyResource,
+			arguments: map[string]interface{}{"content": configMap, "namespace": "team-a"},
+			allowed:   true,
+		},
+		{
+			name:      "namespace argument",
+			tool:      createDeployment,
+			arguments: map[string]interface{}{"name": "nginx", "namespace": "team-a", "content": "kind: Deployment\n"},
+			allowed:   true,
+		},
+		{
+			name: "namespace argument contradicting the content",
+			tool: createDeployment,
+			arguments: map[string]interface{}{"name": "nginx", "namespace": "team-a",
+				"content": "kind: Deployment\nmetadata:\n  namespace: kube-system\n"},
+			allowed: false,
+		},
+		{
+			name: "name argument contradicting the content",
+			tool: createDeployment,
+			arguments: map[string]interface{}{"name": "nginx", "namespace": "team-a",
+				"content": "kind: Deployment\nmetadata:\n  name: coredns\n"},
+			allowed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Name = tt.tool.Name
+			request.Params.Arguments = tt.arguments
+			err := policy.Evaluate(tt.tool, request)
+			if tt.allowed && err != nil {
+				t.Errorf("expected call to be allowed, got %v", err)
+			}
+			if !tt.allowed && err == nil {
+				t.Errorf("expected call to be denied")
+			}
+		})
+	}
+}
+
+func TestTargetOf(t *testing.T) {
+	tool := mcp.NewTool("apply_resource", mcp.WithString("content"), mcp.WithString("namespace"))
+	target, err := TargetOf(tool, map[string]interface{}{"content": configMap, "name": "other", "cluster": "member1"})
+	if err != nil {
+		t.Fatalf("expected target to be resolved, got %v", err)
+	}
+	expected := Target{Namespace: "team-a", Kind: "ConfigMap", Name: "settings"}
+	if target != expected {
+		t.Errorf("expected target %+v, got %+v", expected, target)
+	}
+}
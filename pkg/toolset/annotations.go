@@ -0,0 +1,52 @@
+package toolsets
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"strings"
+)
+
+// readToolAnnotation returns the hints of a read tool: it does not modify the Karmada control-plane,
+// so calling it again has no additional effect.
+func readToolAnnotation() mcp.ToolAnnotation {
+	return mcp.ToolAnnotation{
+		ReadOnlyHint:    mcp.ToBoolPtr(true),
+		DestructiveHint: mcp.ToBoolPtr(false),
+		IdempotentHint:  mcp.ToBoolPtr(true),
+		OpenWorldHint:   mcp.ToBoolPtr(false),
+	}
+}
+
+// writeToolAnnotation returns the hints of a write tool, which may overwrite or delete objects unless
+// its overrides tell it only adds them.
+func writeToolAnnotation() mcp.ToolAnnotation {
+	return mcp.ToolAnnotation{
+		ReadOnlyHint:    mcp.ToBoolPtr(false),
+		DestructiveHint: mcp.ToBoolPtr(true),
+		IdempotentHint:  mcp.ToBoolPtr(false),
+		OpenWorldHint:   mcp.ToBoolPtr(false),
+	}
+}
+
+// annotateTools replaces the hints of the tools with the annotation, a title set by the tool is kept
+// and one is derived from the name of the tool otherwise.
+func annotateTools(tools []server.ServerTool, annotation mcp.ToolAnnotation) []server.ServerTool {
+	for i := range tools {
+		a := annotation
+		a.Title = tools[i].Tool.Annotations.Title
+		if a.Title == "" {
+			a.Title = toolTitle(tools[i].Tool.Name)
+		}
+		tools[i].Tool.Annotations = a
+	}
+	return tools
+}
+
+// toolTitle turns the name of a tool into a human-readable title, e.g. list_clusters into List clusters.
+func toolTitle(name string) string {
+	title := strings.ReplaceAll(name, "_", " ")
+	if title == "" {
+		return title
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
@@ -8,3 +8,7 @@ import (
 func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
 	return server.ServerTool{Tool: tool, Handler: handler}
 }
+
+func NewServerResourceTemplate(template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) server.ServerResourceTemplate {
+	return server.ServerResourceTemplate{Template: template, Handler: handler}
+}
@@ -1,6 +1,9 @@
 package toolsets
 
-import "github.com/mark3labs/mcp-go/server"
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
 
 type Toolset struct {
 	Name        string
@@ -9,6 +12,8 @@ type Toolset struct {
 	readOnly    bool
 	writeTools  []server.ServerTool
 	readTools   []server.ServerTool
+	// resourceTemplates publish the objects the read tools get, so they are only served with the toolset
+	resourceTemplates []server.ServerResourceTemplate
 }
 
 func NewToolset(name string, description string) *Toolset {
@@ -31,13 +36,24 @@ func (t *Toolset) GetAvailableTools() []server.ServerTool {
 	if t.readOnly {
 		return t.readTools
 	}
-	return append(t.readTools, t.writeTools...)
+	// a new slice, appending to readTools could write into its spare capacity shared by concurrent callers
+	tools := make([]server.ServerTool, 0, len(t.readTools)+len(t.writeTools))
+	tools = append(tools, t.readTools...)
+	return append(tools, t.writeTools...)
 }
 
+func (t *Toolset) GetResourceTemplates() []server.ServerResourceTemplate {
+	return t.resourceTemplates
+}
+
+// RegisterTools registers the tools and resource templates of an enabled toolset with the server.
 func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	if !t.Enabled {
 		return
 	}
+	if len(t.resourceTemplates) > 0 {
+		s.AddResourceTemplates(t.resourceTemplates...)
+	}
 	for _, tool := range t.readTools {
 		s.AddTool(tool.Tool, tool.Handler)
 	}
@@ -53,12 +69,48 @@ func (t *Toolset) SetReadOnly() {
 	t.readOnly = true
 }
 
+// AddWriteTools adds tools modifying the Karmada control-plane, they are annotated as destructive and not idempotent.
 func (t *Toolset) AddWriteTools(tools ...server.ServerTool) *Toolset {
-	t.writeTools = append(t.writeTools, tools...)
+	t.writeTools = append(t.writeTools, annotateTools(tools, writeToolAnnotation())...)
 	return t
 }
 
+// AddReadTools adds tools only reading the Karmada control-plane, they are annotated as read-only.
 func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
-	t.readTools = append(t.readTools, tools...)
+	t.readTools = append(t.readTools, annotateTools(tools, readToolAnnotation())...)
+	return t
+}
+
+// AddResourceTemplates adds resource templates publishing the objects the read tools of the toolset get.
+func (t *Toolset) AddResourceTemplates(templates ...server.ServerResourceTemplate) *Toolset {
+	t.resourceTemplates = append(t.resourceTemplates, templates...)
+	return t
+}
+
+// AddToolOptions applies the options to every tool already added to the toolset, for the parameters shared by all tools.
+func (t *Toolset) AddToolOptions(opts ...mcp.ToolOption) *Toolset {
+	for _, tools := range [][]server.ServerTool{t.readTools, t.writeTools} {
+		for i := range tools {
+			for _, opt := range opts {
+				opt(&tools[i].Tool)
+			}
+		}
+	}
+	return t
+}
+
+// OverrideAnnotations applies the options to the annotations of a tool already added to the toolset,
+// for the tools behaving differently from the other read or write tools.
+func (t *Toolset) OverrideAnnotations(name string, opts ...mcp.ToolOption) *Toolset {
+	for _, tools := range [][]server.ServerTool{t.readTools, t.writeTools} {
+		for i := range tools {
+			if tools[i].Tool.Name != name {
+				continue
+			}
+			for _, opt := range opts {
+				opt(&tools[i].Tool)
+			}
+		}
+	}
 	return t
 }
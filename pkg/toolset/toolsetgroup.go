@@ -3,9 +3,12 @@ package toolsets
 import (
 	"fmt"
 	"github.com/mark3labs/mcp-go/server"
+	"sync"
 )
 
 type ToolsetGroup struct {
+	// mu guards the Enabled field of the toolsets, which enable_toolset sets while other tool calls are served
+	mu           sync.RWMutex
 	Toolsets     map[string]*Toolset
 	everythingOn bool
 	readOnly     bool
@@ -27,6 +30,8 @@ func (tg *ToolsetGroup) AddToolset(ts *Toolset) {
 }
 
 func (tg *ToolsetGroup) IsEnabled(name string) bool {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
 	// If everythingOn is true, all features are enabled
 	if tg.everythingOn {
 		return true
@@ -65,16 +70,29 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 }
 
 func (tg *ToolsetGroup) EnableToolset(name string) error {
+	_, err := tg.EnableToolsetOnce(name)
+	return err
+}
+
+// EnableToolsetOnce enables the toolset and returns whether it was disabled, so that concurrent callers
+// enabling the same toolset only register its tools once.
+func (tg *ToolsetGroup) EnableToolsetOnce(name string) (bool, error) {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
-		return fmt.Errorf("toolset %s does not exist", name)
+		return false, fmt.Errorf("toolset %s does not exist", name)
+	}
+	if toolset.Enabled {
+		return false, nil
 	}
 	toolset.Enabled = true
-	tg.Toolsets[name] = toolset
-	return nil
+	return true, nil
 }
 
 func (tg *ToolsetGroup) RegisterTools(s *server.MCPServer) {
+	tg.mu.RLock()
+	defer tg.mu.RUnlock()
 	for _, toolset := range tg.Toolsets {
 		toolset.RegisterTools(s)
 	}